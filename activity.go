@@ -0,0 +1,278 @@
+package todoist
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// maxActivityLimit is the largest page of activity events the server returns
+const maxActivityLimit = 100
+
+// ActivityEventType is what happened to an object in the activity log. https://developer.todoist.com/sync/v8/#activity
+type ActivityEventType string
+
+const (
+	ActivityEventAdded       ActivityEventType = "added"
+	ActivityEventUpdated     ActivityEventType = "updated"
+	ActivityEventCompleted   ActivityEventType = "completed"
+	ActivityEventUncompleted ActivityEventType = "uncompleted"
+	ActivityEventDeleted     ActivityEventType = "deleted"
+	ActivityEventArchived    ActivityEventType = "archived"
+	ActivityEventUnarchived  ActivityEventType = "unarchived"
+	ActivityEventShared      ActivityEventType = "shared"
+	ActivityEventLeft        ActivityEventType = "left"
+)
+
+// ActivityObjectType is the kind of object an activity event is about
+type ActivityObjectType string
+
+const (
+	ActivityObjectItem    ActivityObjectType = "item"
+	ActivityObjectNote    ActivityObjectType = "note"
+	ActivityObjectProject ActivityObjectType = "project"
+)
+
+// ActivityExtraData holds the details an event records about the object, which depend on its type. Fields starting with Last hold the
+// value before an update.
+type ActivityExtraData struct {
+	Content            string `json:"content"`
+	LastContent        string `json:"last_content"`
+	Description        string `json:"description"`
+	LastDescription    string `json:"last_description"`
+	DueDate            string `json:"due_date"`
+	LastDueDate        string `json:"last_due_date"`
+	ResponsibleUID     int64  `json:"responsible_uid"`
+	LastResponsibleUID int64  `json:"last_responsible_uid"`
+	Name               string `json:"name"` // of a project
+	LastName           string `json:"last_name"`
+	Client             string `json:"client"` // the app that made the change
+}
+
+// ActivityEvent is a single entry of the activity log
+type ActivityEvent struct {
+	ID              int64              `json:"id"`
+	ObjectType      ActivityObjectType `json:"object_type"`
+	ObjectID        int64              `json:"object_id"`
+	EventType       ActivityEventType  `json:"event_type"`
+	EventDate       time.Time          `json:"event_date"`
+	ParentProjectID int64              `json:"parent_project_id"`
+	ParentItemID    int64              `json:"parent_item_id"`
+	InitiatorID     int64              `json:"initiator_id"` // zero when the user made the change themselves
+	ExtraData       ActivityExtraData  `json:"-"`
+	RawExtraData    json.RawMessage    `json:"extra_data"`
+}
+
+// TaskID returns the id of the task the event is about, or the task of the comment it is about
+func (e *ActivityEvent) TaskID() int64 {
+	switch e.ObjectType {
+	case ActivityObjectItem:
+		return e.ObjectID
+	case ActivityObjectNote:
+		return e.ParentItemID
+	}
+	return 0
+}
+
+// ProjectID returns the id of the project the event is about, or the project its task or comment is in
+func (e *ActivityEvent) ProjectID() int64 {
+	if e.ObjectType == ActivityObjectProject {
+		return e.ObjectID
+	}
+	return e.ParentProjectID
+}
+
+// CommentID returns the id of the comment the event is about, if it is about one
+func (e *ActivityEvent) CommentID() int64 {
+	if e.ObjectType == ActivityObjectNote {
+		return e.ObjectID
+	}
+	return 0
+}
+
+// ActivityOptions narrow down the activity events to get. The zero value gets the most recent events of every kind.
+type ActivityOptions struct {
+	ObjectType      ActivityObjectType
+	ObjectID        int64 // requires the ObjectType
+	EventType       ActivityEventType
+	ParentProjectID int64
+	ParentItemID    int64
+	InitiatorID     int64
+	Since           time.Time
+	Until           time.Time
+	Limit           int // up to 100; the server defaults to 30
+	Offset          int
+}
+
+// Validate checks the options for values the server rejects. Nil options are valid.
+func (o *ActivityOptions) Validate() error {
+	if o == nil {
+		return nil
+	}
+	if o.ObjectID != 0 && o.ObjectType == "" {
+		return errors.New("object id requires an object type")
+	}
+	if o.Limit < 0 || o.Limit > maxActivityLimit {
+		return fmt.Errorf("limit must be between 0 and %d", maxActivityLimit)
+	}
+	if o.Offset < 0 {
+		return errors.New("offset cannot be negative")
+	}
+	if !o.Since.IsZero() && !o.Until.IsZero() && o.Until.Before(o.Since) {
+		return errors.New("until cannot be before since")
+	}
+	return nil
+}
+
+func (o *ActivityOptions) queryParams() map[string]string {
+	data := map[string]string{}
+	if o == nil {
+		return data
+	}
+	if o.ObjectType != "" {
+		data["object_type"] = string(o.ObjectType)
+	}
+	if o.ObjectID != 0 {
+		data["object_id"] = fmt.Sprintf("%d", o.ObjectID)
+	}
+	if o.EventType != "" {
+		data["event_type"] = string(o.EventType)
+	}
+	if o.ParentProjectID != 0 {
+		data["parent_project_id"] = fmt.Sprintf("%d", o.ParentProjectID)
+	}
+	if o.ParentItemID != 0 {
+		data["parent_item_id"] = fmt.Sprintf("%d", o.ParentItemID)
+	}
+	if o.InitiatorID != 0 {
+		data["initiator_id"] = fmt.Sprintf("%d", o.InitiatorID)
+	}
+	if !o.Since.IsZero() {
+		data["since"] = o.Since.UTC().Format(syncTimeFormat)
+	}
+	if !o.Until.IsZero() {
+		data["until"] = o.Until.UTC().Format(syncTimeFormat)
+	}
+	if o.Limit != 0 {
+		data["limit"] = strconv.Itoa(o.Limit)
+	}
+	if o.Offset != 0 {
+		data["offset"] = strconv.Itoa(o.Offset)
+	}
+	return data
+}
+
+// ActivityPage is a page of activity events, most recent first, along with how many events match in total
+type ActivityPage struct {
+	Events []ActivityEvent `json:"events"`
+	Count  int             `json:"count"`
+}
+
+// GetActivity gets a page of the activity log. The options may be nil. This requires a premium account.
+// https://developer.todoist.com/sync/v8/#get-activity-logs
+func GetActivity(token string, opts *ActivityOptions) (*ActivityPage, error) {
+	return clientForToken(token).GetActivity(opts)
+}
+
+// GetActivityWithContext is GetActivity with a context that can cancel the call or set its deadline
+func GetActivityWithContext(ctx context.Context, token string, opts *ActivityOptions) (*ActivityPage, error) {
+	return clientForToken(token).GetActivityWithContext(ctx, opts)
+}
+
+// Activity returns an iterator over the activity events that match the options, starting at their offset. The limit sets the page size,
+// which defaults to the largest the server allows. The options may be nil.
+func Activity(token string, opts *ActivityOptions) *ActivityIterator {
+	return clientForToken(token).Activity(opts)
+}
+
+// ActivityWithContext is Activity with a context that can cancel the calls or set their deadline
+func ActivityWithContext(ctx context.Context, token string, opts *ActivityOptions) *ActivityIterator {
+	return clientForToken(token).ActivityWithContext(ctx, opts)
+}
+
+// GetActivity gets a page of the activity log. The options may be nil. This requires a premium account.
+// https://developer.todoist.com/sync/v8/#get-activity-logs
+func (c *Client) GetActivity(opts *ActivityOptions) (*ActivityPage, error) {
+	return c.GetActivityWithContext(context.Background(), opts)
+}
+
+// GetActivityWithContext is GetActivity with a context that can cancel the call or set its deadline
+func (c *Client) GetActivityWithContext(ctx context.Context, opts *ActivityOptions) (*ActivityPage, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	resp, err := c.makeCall(ctx, EndpointNameGetActivity, map[string]string{}, opts.queryParams())
+	if err != nil {
+		return nil, err
+	}
+	page := &ActivityPage{}
+	if err = json.Unmarshal(resp.Body, page); err != nil {
+		return nil, err
+	}
+	for i := range page.Events {
+		event := &page.Events[i]
+		if len(event.RawExtraData) == 0 || string(event.RawExtraData) == "null" {
+			continue
+		}
+		if err = json.Unmarshal(event.RawExtraData, &event.ExtraData); err != nil {
+			return nil, fmt.Errorf("could not decode the extra data of event %d: %w", event.ID, err)
+		}
+	}
+	return page, nil
+}
+
+// ActivityIterator walks through the activity log, fetching the next page when the current one runs out
+type ActivityIterator struct {
+	client *Client
+	ctx    context.Context
+	opts   ActivityOptions
+
+	page []ActivityEvent
+	done bool
+}
+
+// Activity returns an iterator over the activity events that match the options, starting at their offset. The limit sets the page size,
+// which defaults to the largest the server allows. The options may be nil.
+func (c *Client) Activity(opts *ActivityOptions) *ActivityIterator {
+	return c.ActivityWithContext(context.Background(), opts)
+}
+
+// ActivityWithContext is Activity with a context that can cancel the calls or set their deadline
+func (c *Client) ActivityWithContext(ctx context.Context, opts *ActivityOptions) *ActivityIterator {
+	it := &ActivityIterator{
+		client: c,
+		ctx:    ctx,
+	}
+	if opts != nil {
+		it.opts = *opts
+	}
+	if it.opts.Limit == 0 {
+		it.opts.Limit = maxActivityLimit
+	}
+	return it
+}
+
+// Next returns the next activity event, or ErrIteratorDone when there are no more
+func (it *ActivityIterator) Next() (*ActivityEvent, error) {
+	if len(it.page) == 0 {
+		if it.done {
+			return nil, ErrIteratorDone
+		}
+		page, err := it.client.GetActivityWithContext(it.ctx, &it.opts)
+		if err != nil {
+			return nil, err
+		}
+		it.page = page.Events
+		it.opts.Offset += len(page.Events)
+		it.done = len(page.Events) < it.opts.Limit || it.opts.Offset >= page.Count
+		if len(it.page) == 0 {
+			return nil, ErrIteratorDone
+		}
+	}
+	event := it.page[0]
+	it.page = it.page[1:]
+	return &event, nil
+}
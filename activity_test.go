@@ -0,0 +1,106 @@
+package todoist
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testActivityEvents = []map[string]interface{}{
+	{"id": 1, "object_type": "item", "object_id": 10, "event_type": "updated", "event_date": "2021-06-03T10:00:00Z", "parent_project_id": 2,
+		"extra_data": map[string]interface{}{"content": "Write the report", "last_content": "Write report", "due_date": "2021-06-05T10:00:00Z", "client": "web"}},
+	{"id": 2, "object_type": "note", "object_id": 40, "event_type": "added", "event_date": "2021-06-02T10:00:00Z", "parent_project_id": 2,
+		"parent_item_id": 10, "initiator_id": 7, "extra_data": map[string]interface{}{"content": "First draft attached"}},
+	{"id": 3, "object_type": "project", "object_id": 2, "event_type": "archived", "event_date": "2021-06-01T10:00:00Z",
+		"extra_data": map[string]interface{}{"name": "Work"}},
+	{"id": 4, "object_type": "item", "object_id": 11, "event_type": "completed", "event_date": "2021-05-31T10:00:00Z", "parent_project_id": 2,
+		"extra_data": nil},
+}
+
+func TestGetActivity(t *testing.T) {
+	fake, client := newFakeTodoistClient(t)
+	fake.events = testActivityEvents
+
+	_, err := client.GetActivity(&ActivityOptions{ObjectID: 10})
+	assert.NotNil(t, err)
+	_, err = client.GetActivity(&ActivityOptions{Limit: 101})
+	assert.NotNil(t, err)
+	assert.Empty(t, fake.calls("activity/get"))
+
+	page, err := client.GetActivity(&ActivityOptions{
+		ObjectType:      ActivityObjectItem,
+		ObjectID:        10,
+		EventType:       ActivityEventUpdated,
+		ParentProjectID: 2,
+		InitiatorID:     7,
+		Since:           time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC),
+		Until:           time.Date(2021, 6, 8, 0, 0, 0, 0, time.UTC),
+	})
+	require.Nil(t, err)
+	queries := fake.calls("activity/get")
+	require.Len(t, queries, 1)
+	assert.Equal(t, url.Values{
+		"object_type":       {"item"},
+		"object_id":         {"10"},
+		"event_type":        {"updated"},
+		"parent_project_id": {"2"},
+		"initiator_id":      {"7"},
+		"since":             {"2021-06-01T00:00"},
+		"until":             {"2021-06-08T00:00"},
+	}, queries[0])
+
+	require.Len(t, page.Events, 4)
+	assert.Equal(t, 4, page.Count)
+
+	updated := page.Events[0]
+	assert.Equal(t, ActivityEventUpdated, updated.EventType)
+	assert.Equal(t, time.Date(2021, 6, 3, 10, 0, 0, 0, time.UTC), updated.EventDate)
+	assert.Equal(t, "Write the report", updated.ExtraData.Content)
+	assert.Equal(t, "Write report", updated.ExtraData.LastContent)
+	assert.Equal(t, "web", updated.ExtraData.Client)
+	assert.Equal(t, int64(10), updated.TaskID())
+	assert.Equal(t, int64(2), updated.ProjectID())
+	assert.Zero(t, updated.CommentID())
+
+	note := page.Events[1]
+	assert.Equal(t, int64(10), note.TaskID())
+	assert.Equal(t, int64(40), note.CommentID())
+	assert.Equal(t, int64(7), note.InitiatorID)
+
+	archived := page.Events[2]
+	assert.Equal(t, ActivityEventArchived, archived.EventType)
+	assert.Equal(t, int64(2), archived.ProjectID())
+	assert.Zero(t, archived.TaskID())
+	assert.Equal(t, "Work", archived.ExtraData.Name)
+
+	assert.Equal(t, ActivityExtraData{}, page.Events[3].ExtraData)
+}
+
+func TestActivityIterator(t *testing.T) {
+	fake, client := newFakeTodoistClient(t)
+	fake.events = testActivityEvents
+
+	it := client.ActivityWithContext(context.Background(), &ActivityOptions{Limit: 2})
+	ids := []int64{}
+	for {
+		event, err := it.Next()
+		if err == ErrIteratorDone {
+			break
+		}
+		require.Nil(t, err)
+		ids = append(ids, event.ID)
+	}
+	assert.Equal(t, []int64{1, 2, 3, 4}, ids)
+	// the count tells the iterator the second page is the last, even though it is full
+	assert.Len(t, fake.calls("activity/get"), 2)
+
+	it = client.Activity(&ActivityOptions{Limit: 3, Offset: 1})
+	event, err := it.Next()
+	require.Nil(t, err)
+	assert.Equal(t, int64(2), event.ID)
+	assert.Equal(t, "1", fake.calls("activity/get")[2].Get("offset"))
+}
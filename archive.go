@@ -0,0 +1,207 @@
+package todoist
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ArchivedListOptions page through archived projects. The zero value gets the first page at the server's default size.
+type ArchivedListOptions struct {
+	Limit  int
+	Offset int
+}
+
+func (o *ArchivedListOptions) queryParams() (map[string]string, error) {
+	data := map[string]string{}
+	if o == nil {
+		return data, nil
+	}
+	if o.Limit < 0 || o.Offset < 0 {
+		return nil, errors.New("limit and offset cannot be negative")
+	}
+	if o.Limit != 0 {
+		data["limit"] = strconv.Itoa(o.Limit)
+	}
+	if o.Offset != 0 {
+		data["offset"] = strconv.Itoa(o.Offset)
+	}
+	return data, nil
+}
+
+// ArchiveProject archives a project and its subprojects, keeping their tasks and history. https://developer.todoist.com/sync/v8/#archive-a-project
+func ArchiveProject(token string, projectID int64) error {
+	return clientForToken(token).ArchiveProject(projectID)
+}
+
+// ArchiveProjectWithContext is ArchiveProject with a context that can cancel the call or set its deadline
+func ArchiveProjectWithContext(ctx context.Context, token string, projectID int64) error {
+	return clientForToken(token).ArchiveProjectWithContext(ctx, projectID)
+}
+
+// UnarchiveProject restores an archived project. https://developer.todoist.com/sync/v8/#unarchive-a-project
+func UnarchiveProject(token string, projectID int64) error {
+	return clientForToken(token).UnarchiveProject(projectID)
+}
+
+// UnarchiveProjectWithContext is UnarchiveProject with a context that can cancel the call or set its deadline
+func UnarchiveProjectWithContext(ctx context.Context, token string, projectID int64) error {
+	return clientForToken(token).UnarchiveProjectWithContext(ctx, projectID)
+}
+
+// GetArchivedProjects gets a page of the archived projects. The options may be nil. https://developer.todoist.com/sync/v8/#get-archived-projects
+func GetArchivedProjects(token string, opts *ArchivedListOptions) ([]Project, error) {
+	return clientForToken(token).GetArchivedProjects(opts)
+}
+
+// GetArchivedProjectsWithContext is GetArchivedProjects with a context that can cancel the call or set its deadline
+func GetArchivedProjectsWithContext(ctx context.Context, token string, opts *ArchivedListOptions) ([]Project, error) {
+	return clientForToken(token).GetArchivedProjectsWithContext(ctx, opts)
+}
+
+// ArchiveSection archives a section, completing its tasks. https://developer.todoist.com/sync/v8/#archive-a-section
+func ArchiveSection(token string, sectionID int64) error {
+	return clientForToken(token).ArchiveSection(sectionID)
+}
+
+// ArchiveSectionWithContext is ArchiveSection with a context that can cancel the call or set its deadline
+func ArchiveSectionWithContext(ctx context.Context, token string, sectionID int64) error {
+	return clientForToken(token).ArchiveSectionWithContext(ctx, sectionID)
+}
+
+// UnarchiveSection restores an archived section. https://developer.todoist.com/sync/v8/#unarchive-a-section
+func UnarchiveSection(token string, sectionID int64) error {
+	return clientForToken(token).UnarchiveSection(sectionID)
+}
+
+// UnarchiveSectionWithContext is UnarchiveSection with a context that can cancel the call or set its deadline
+func UnarchiveSectionWithContext(ctx context.Context, token string, sectionID int64) error {
+	return clientForToken(token).UnarchiveSectionWithContext(ctx, sectionID)
+}
+
+// GetArchivedSections gets the archived sections of a project, all at once, since the endpoint does not page.
+// https://developer.todoist.com/sync/v8/#get-archived-sections
+func GetArchivedSections(token string, projectID int64) ([]Section, error) {
+	return clientForToken(token).GetArchivedSections(projectID)
+}
+
+// GetArchivedSectionsWithContext is GetArchivedSections with a context that can cancel the call or set its deadline
+func GetArchivedSectionsWithContext(ctx context.Context, token string, projectID int64) ([]Section, error) {
+	return clientForToken(token).GetArchivedSectionsWithContext(ctx, projectID)
+}
+
+// ArchiveProject archives a project and its subprojects, keeping their tasks and history. https://developer.todoist.com/sync/v8/#archive-a-project
+func (c *Client) ArchiveProject(projectID int64) error {
+	return c.ArchiveProjectWithContext(context.Background(), projectID)
+}
+
+// ArchiveProjectWithContext is ArchiveProject with a context that can cancel the call or set its deadline
+func (c *Client) ArchiveProjectWithContext(ctx context.Context, projectID int64) error {
+	if projectID == 0 {
+		return errors.New("project id is required")
+	}
+	batch := NewCommandBatch()
+	_, err := c.executeCommand(ctx, batch, batch.ArchiveProject(IDRef(projectID)))
+	return err
+}
+
+// UnarchiveProject restores an archived project. https://developer.todoist.com/sync/v8/#unarchive-a-project
+func (c *Client) UnarchiveProject(projectID int64) error {
+	return c.UnarchiveProjectWithContext(context.Background(), projectID)
+}
+
+// UnarchiveProjectWithContext is UnarchiveProject with a context that can cancel the call or set its deadline
+func (c *Client) UnarchiveProjectWithContext(ctx context.Context, projectID int64) error {
+	if projectID == 0 {
+		return errors.New("project id is required")
+	}
+	batch := NewCommandBatch()
+	_, err := c.executeCommand(ctx, batch, batch.UnarchiveProject(IDRef(projectID)))
+	return err
+}
+
+// GetArchivedProjects gets a page of the archived projects. The options may be nil. https://developer.todoist.com/sync/v8/#get-archived-projects
+func (c *Client) GetArchivedProjects(opts *ArchivedListOptions) ([]Project, error) {
+	return c.GetArchivedProjectsWithContext(context.Background(), opts)
+}
+
+// GetArchivedProjectsWithContext is GetArchivedProjects with a context that can cancel the call or set its deadline
+func (c *Client) GetArchivedProjectsWithContext(ctx context.Context, opts *ArchivedListOptions) ([]Project, error) {
+	projects := []Project{}
+	data, err := opts.queryParams()
+	if err != nil {
+		return projects, err
+	}
+	resp, err := c.makeCall(ctx, EndpointNameGetArchivedProjects, map[string]string{}, data)
+	if err != nil {
+		return projects, err
+	}
+	decoded := []syncProject{}
+	if err = json.Unmarshal(resp.Body, &decoded); err != nil {
+		return projects, err
+	}
+	for i := range decoded {
+		projects = append(projects, decoded[i].toProject())
+	}
+	return projects, nil
+}
+
+// ArchiveSection archives a section, completing its tasks. https://developer.todoist.com/sync/v8/#archive-a-section
+func (c *Client) ArchiveSection(sectionID int64) error {
+	return c.ArchiveSectionWithContext(context.Background(), sectionID)
+}
+
+// ArchiveSectionWithContext is ArchiveSection with a context that can cancel the call or set its deadline
+func (c *Client) ArchiveSectionWithContext(ctx context.Context, sectionID int64) error {
+	if sectionID == 0 {
+		return errors.New("section id is required")
+	}
+	batch := NewCommandBatch()
+	_, err := c.executeCommand(ctx, batch, batch.ArchiveSection(IDRef(sectionID)))
+	return err
+}
+
+// UnarchiveSection restores an archived section. https://developer.todoist.com/sync/v8/#unarchive-a-section
+func (c *Client) UnarchiveSection(sectionID int64) error {
+	return c.UnarchiveSectionWithContext(context.Background(), sectionID)
+}
+
+// UnarchiveSectionWithContext is UnarchiveSection with a context that can cancel the call or set its deadline
+func (c *Client) UnarchiveSectionWithContext(ctx context.Context, sectionID int64) error {
+	if sectionID == 0 {
+		return errors.New("section id is required")
+	}
+	batch := NewCommandBatch()
+	_, err := c.executeCommand(ctx, batch, batch.UnarchiveSection(IDRef(sectionID)))
+	return err
+}
+
+// GetArchivedSections gets the archived sections of a project, all at once, since the endpoint does not page.
+// https://developer.todoist.com/sync/v8/#get-archived-sections
+func (c *Client) GetArchivedSections(projectID int64) ([]Section, error) {
+	return c.GetArchivedSectionsWithContext(context.Background(), projectID)
+}
+
+// GetArchivedSectionsWithContext is GetArchivedSections with a context that can cancel the call or set its deadline
+func (c *Client) GetArchivedSectionsWithContext(ctx context.Context, projectID int64) ([]Section, error) {
+	sections := []Section{}
+	if projectID == 0 {
+		return sections, errors.New("project id is required")
+	}
+	resp, err := c.makeCall(ctx, EndpointNameGetArchivedSections, map[string]string{}, map[string]string{
+		"project_id": fmt.Sprintf("%d", projectID),
+	})
+	if err != nil {
+		return sections, err
+	}
+	decoded := []syncSection{}
+	if err = json.Unmarshal(resp.Body, &decoded); err != nil {
+		return sections, err
+	}
+	for i := range decoded {
+		sections = append(sections, decoded[i].toSection())
+	}
+	return sections, nil
+}
@@ -0,0 +1,72 @@
+package todoist
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveCommands(t *testing.T) {
+	fake, client := newFakeTodoistClient(t)
+	fake.seed("projects", map[string]interface{}{"id": 2, "name": "Work"})
+	fake.seed("sections", map[string]interface{}{"id": 20, "name": "Done", "project_id": 2})
+
+	require.Nil(t, client.ArchiveProject(2))
+	assert.Equal(t, true, fake.collections["projects"][2]["is_archived"])
+	require.Nil(t, client.UnarchiveProject(2))
+	assert.Equal(t, false, fake.collections["projects"][2]["is_archived"])
+	require.Nil(t, client.ArchiveSection(20))
+	require.Nil(t, client.UnarchiveSection(20))
+	assert.Equal(t, []string{"project_archive", "project_unarchive", "section_archive", "section_unarchive"}, fake.commandTypes())
+	assert.Equal(t, map[string]interface{}{"id": float64(20)}, fake.commands()[3]["args"])
+
+	err := client.ArchiveProject(404)
+	commandErr := &CommandError{}
+	require.ErrorAs(t, err, &commandErr)
+	assert.Equal(t, "Project not found", commandErr.Message)
+
+	assert.NotNil(t, client.ArchiveProject(0))
+	assert.NotNil(t, client.UnarchiveSection(0))
+	assert.Len(t, fake.commands(), 5)
+}
+
+func TestGetArchived(t *testing.T) {
+	fake, client := newFakeTodoistClient(t)
+	fake.seed("projects",
+		map[string]interface{}{"id": 2, "name": "Work"},
+		map[string]interface{}{"id": 3, "name": "Old client", "is_archived": true, "child_order": 4},
+		map[string]interface{}{"id": 4, "name": "Older client", "is_archived": true},
+	)
+	fake.seed("sections",
+		map[string]interface{}{"id": 20, "name": "Doing", "project_id": 2},
+		map[string]interface{}{"id": 30, "name": "Done", "project_id": 2, "is_archived": true, "section_order": 2},
+		map[string]interface{}{"id": 40, "name": "Elsewhere", "project_id": 4, "is_archived": true},
+	)
+
+	projects, err := client.GetArchivedProjects(&ArchivedListOptions{Limit: 1, Offset: 1})
+	require.Nil(t, err)
+	require.Len(t, projects, 1)
+	assert.Equal(t, "Older client", projects[0].Name)
+	assert.Equal(t, url.Values{"limit": {"1"}, "offset": {"1"}}, fake.calls("projects/get_archived")[0])
+	projects, err = client.GetArchivedProjects(nil)
+	require.Nil(t, err)
+	require.Len(t, projects, 2)
+	assert.Equal(t, int64(4), projects[0].Order)
+	assert.True(t, projects[0].IsArchived)
+
+	// archiving through a command shows up in the listing
+	require.Nil(t, client.ArchiveSection(20))
+	sections, err := client.GetArchivedSections(2)
+	require.Nil(t, err)
+	require.Len(t, sections, 2)
+	assert.Equal(t, Section{ID: 30, ProjectID: 2, Name: "Done", Order: 2, IsArchived: true}, sections[1])
+	assert.Equal(t, url.Values{"project_id": {"2"}}, fake.calls("sections/get_archived")[0])
+
+	_, err = client.GetArchivedSections(0)
+	assert.NotNil(t, err)
+	_, err = client.GetArchivedProjects(&ArchivedListOptions{Limit: -1})
+	assert.NotNil(t, err)
+	assert.Len(t, fake.calls("projects/get_archived"), 2)
+}
@@ -1,10 +1,12 @@
 package todoist
 
 import (
+	"bytes"
+	"context"
 	"errors"
-	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"gopkg.in/resty.v1"
 )
@@ -15,6 +17,18 @@ type endpoint struct {
 	Path       string
 	PathParams map[string]string
 	Method     string
+	Sync       bool // the endpoint belongs to the Sync API instead of the REST API
+	Form       bool // the body is sent form encoded instead of as JSON, so it must be a map[string]string{}
+	Multipart  bool // the body is sent as a multipart form with a file, so it must be a multipartBody
+	Idempotent bool // the endpoint is safe to retry even though its method is not
+}
+
+// multipartBody is the body of a multipart call: form fields and a single file
+type multipartBody struct {
+	Fields    map[string]string
+	FileParam string
+	FileName  string
+	File      []byte
 }
 
 type todoistResponse struct {
@@ -22,7 +36,133 @@ type todoistResponse struct {
 	Body       []byte
 }
 
-func makeCall(token string, endpointName string, pathParams map[string]string, data interface{}) (todoistResponse, error) {
+const (
+	// defaultBaseURL is the root of the Todoist API
+	defaultBaseURL = "https://api.todoist.com"
+	// defaultAPIVersion is the version of the REST API the endpoints are written against
+	defaultAPIVersion = "v1"
+	// defaultSyncAPIVersion is the version of the Sync API the sync endpoints are written against
+	defaultSyncAPIVersion = "v8"
+)
+
+// Client talks to the Todoist API on behalf of a single user. Everything the Client needs, such as the token, the API location, and the
+// HTTP client, is owned by the Client itself, so integrations serving many users can hold one Client per user without any shared mutable
+// state. A Client is safe for concurrent use.
+type Client struct {
+	token          string
+	baseURL        string
+	apiVersion     string
+	syncAPIVersion string
+	httpClient     *http.Client
+	rest           *resty.Client
+	retryPolicy    RetryPolicy
+	rateLimit      RateLimit
+}
+
+// ClientOption configures a Client when it is created with NewClient
+type ClientOption func(*Client)
+
+// WithHTTPClient sets the http.Client used for the calls. If not provided, a new http.Client is created for the Client.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		if httpClient != nil {
+			c.httpClient = httpClient
+		}
+	}
+}
+
+// WithBaseURL points the Client at a different host than https://api.todoist.com, such as a local stand-in, a recording proxy, or an
+// egress gateway. The REST API is expected at /rest/{version} below it, and the Sync API at /sync/{version}.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) {
+		if baseURL != "" {
+			c.baseURL = strings.TrimRight(baseURL, "/")
+		}
+	}
+}
+
+// WithAPIVersion sets the version of the REST API to call, such as "v1"
+func WithAPIVersion(apiVersion string) ClientOption {
+	return func(c *Client) {
+		if apiVersion != "" {
+			c.apiVersion = strings.Trim(apiVersion, "/")
+		}
+	}
+}
+
+// WithSyncAPIVersion sets the version of the Sync API to call, such as "v8"
+func WithSyncAPIVersion(syncAPIVersion string) ClientOption {
+	return func(c *Client) {
+		if syncAPIVersion != "" {
+			c.syncAPIVersion = strings.Trim(syncAPIVersion, "/")
+		}
+	}
+}
+
+// NewClient creates a new Client for the user that owns the token
+func NewClient(token string, opts ...ClientOption) *Client {
+	c := &Client{
+		token:          token,
+		baseURL:        defaultBaseURL,
+		apiVersion:     defaultAPIVersion,
+		syncAPIVersion: defaultSyncAPIVersion,
+		retryPolicy:    DefaultRetryPolicy(),
+		rateLimit:      DefaultRateLimit(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.httpClient == nil {
+		c.httpClient = &http.Client{}
+	}
+	c.rest = resty.NewWithClient(c.httpClient)
+	return c
+}
+
+// Token returns the auth token the Client uses for its calls
+func (c *Client) Token() string {
+	return c.token
+}
+
+// BaseURL returns the root URL the Client sends its calls to
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// APIVersion returns the version of the REST API the Client calls
+func (c *Client) APIVersion() string {
+	return c.apiVersion
+}
+
+// SyncAPIVersion returns the version of the Sync API the Client calls
+func (c *Client) SyncAPIVersion() string {
+	return c.syncAPIVersion
+}
+
+// withToken returns a copy of the client that uses a different token but shares everything else, including the underlying HTTP client
+func (c *Client) withToken(token string) *Client {
+	copied := *c
+	copied.token = token
+	return &copied
+}
+
+// endpointURL resolves the endpoint's path, with its path params filled in, against the Client's base URL and the version of the API the
+// endpoint belongs to
+func (c *Client) endpointURL(ep endpoint, pathParams map[string]string) string {
+	url := c.baseURL + "/rest/" + c.apiVersion + ep.Path
+	if ep.Sync {
+		url = c.baseURL + "/sync/" + c.syncAPIVersion + ep.Path
+	}
+	for k, v := range pathParams {
+		url = strings.Replace(url, ":"+k, v, -1)
+	}
+	return url
+}
+
+// makeCall performs the call to the endpoint, waiting on the Client's rate limit before each attempt and retrying according to its
+// RetryPolicy. The context is attached to the underlying HTTP request, so cancelling it or reaching its deadline aborts the call and any
+// wait between attempts.
+func (c *Client) makeCall(ctx context.Context, endpointName string, pathParams map[string]string, data interface{}) (todoistResponse, error) {
 	result := todoistResponse{}
 
 	// first, find the endpoint
@@ -31,28 +171,81 @@ func makeCall(token string, endpointName string, pathParams map[string]string, d
 		return result, errors.New("endpoint not found")
 	}
 
-	if token == "" && config.AuthToken != "" {
-		token = config.AuthToken
+	// data depends entirely on the call
+	if data != nil && (ep.Method == http.MethodGet || ep.Method == http.MethodDelete) {
+		if _, pOK := data.(map[string]string); !pOK {
+			return result, errors.New("for GET and DELETE, the body must be a map[string]string{}")
+		}
+	}
+	if data != nil && ep.Form {
+		if _, pOK := data.(map[string]string); !pOK {
+			return result, errors.New("for form encoded calls, the body must be a map[string]string{}")
+		}
+	}
+	if ep.Multipart {
+		if _, pOK := data.(multipartBody); !pOK {
+			return result, errors.New("for multipart calls, the body must be a multipartBody")
+		}
 	}
 
-	client := resty.New()
-	r := client.R().SetAuthToken(token)
+	url := c.endpointURL(ep, pathParams)
+	idempotencyKey := idempotencyKeyFromContext(ctx)
+	// only calls that are safe to repeat are retried
+	retryable := ep.Method == http.MethodGet || ep.Method == http.MethodPut || ep.Method == http.MethodDelete || ep.Idempotent || idempotencyKey != ""
+
+	limiter := c.rateLimiter()
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		if limiter != nil {
+			if err := limiter.wait(ctx); err != nil {
+				return result, err
+			}
+		}
+		var err error
+		result, err = c.send(ctx, endpointName, ep, url, data, idempotencyKey)
+		if err == nil || !retryable {
+			return result, err
+		}
+		wait, retry := c.retryPolicy.nextWait(attempt, time.Since(start), err)
+		if !retry {
+			return result, err
+		}
+		if c.retryPolicy.OnRetry != nil {
+			c.retryPolicy.OnRetry(RetryAttempt{
+				Endpoint: endpointName,
+				Attempt:  attempt,
+				Wait:     wait,
+				Err:      err,
+			})
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return result, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
 
-	// build the URL
-	url := "https://api.todoist.com/rest/v1" + ep.Path
-	for k, v := range pathParams {
-		url = strings.Replace(url, ":"+k, v, -1)
+// send makes a single attempt at a call
+func (c *Client) send(ctx context.Context, endpointName string, ep endpoint, url string, data interface{}, idempotencyKey string) (todoistResponse, error) {
+	result := todoistResponse{}
+
+	r := c.rest.R().SetContext(ctx).SetAuthToken(c.token)
+	if idempotencyKey != "" {
+		r.SetHeader("X-Request-Id", idempotencyKey)
 	}
 
-	// data depends entirely on the call
 	if data != nil {
 		if ep.Method == http.MethodGet || ep.Method == http.MethodDelete {
-			// try to convert
-			p, pOK := data.(map[string]string)
-			if !pOK {
-				return result, errors.New("for GET and DELETE, the body must be a map[string]string{}")
-			}
-			r.SetQueryParams(p)
+			r.SetQueryParams(data.(map[string]string))
+		} else if ep.Form {
+			r.SetFormData(data.(map[string]string))
+		} else if ep.Multipart {
+			body := data.(multipartBody)
+			// a new reader for every attempt, as a retry sends the file again
+			r.SetFormData(body.Fields).SetFileReader(body.FileParam, body.FileName, bytes.NewReader(body.File))
 		} else if ep.Method == http.MethodPost || ep.Method == http.MethodPut || ep.Method == http.MethodPatch {
 			r.SetBody(data)
 		}
@@ -77,9 +270,19 @@ func makeCall(token string, endpointName string, pathParams map[string]string, d
 	}
 	result.StatusCode = resp.StatusCode()
 
-	if resp.StatusCode() == http.StatusBadRequest || resp.StatusCode() == http.StatusUnauthorized || resp.StatusCode() == http.StatusForbidden || resp.StatusCode() == http.StatusNotFound {
-		// body is a string, but we need to flag an error
-		err = fmt.Errorf(strings.TrimRight(string(resp.Body()), "\n"))
+	if resp.StatusCode() < http.StatusOK || resp.StatusCode() >= http.StatusMultipleChoices {
+		requestID := resp.Header().Get("X-Request-Id")
+		if requestID == "" {
+			requestID = idempotencyKey
+		}
+		err = &APIError{
+			StatusCode: resp.StatusCode(),
+			Endpoint:   endpointName,
+			Method:     ep.Method,
+			Body:       string(resp.Body()),
+			RequestID:  requestID,
+			RetryAfter: parseRetryAfter(resp.Header().Get("Retry-After")),
+		}
 	}
 	result.Body = resp.Body()
 	return result, err
@@ -94,6 +297,8 @@ const (
 	EndpointNameGetProject    = "GetProject"
 	EndpointNameUpdateProject = "UpdateProject"
 
+	EndpointNameGetAllCollaborators = "GetAllCollaborators"
+
 	// tasks
 
 	EndpointNameGetAllActiveTasks = "GetTasks"
@@ -119,6 +324,30 @@ const (
 	EndpointNameCreateLabel  = "CreateLabel"
 	EndpointNameUpdateLabel  = "UpdateLabel"
 	EndpointNameDeleteLabel  = "DeleteLabel"
+
+	// comments
+
+	EndpointNameGetAllComments = "GetAllComments"
+	EndpointNameGetComment     = "GetComment"
+	EndpointNameCreateComment  = "CreateComment"
+	EndpointNameUpdateComment  = "UpdateComment"
+	EndpointNameDeleteComment  = "DeleteComment"
+
+	// sync
+
+	EndpointNameSync         = "Sync"
+	EndpointNameQuickAddTask = "QuickAddTask"
+
+	EndpointNameGetCompletedTasks = "GetCompletedTasks"
+	EndpointNameGetActivity       = "GetActivity"
+
+	EndpointNameGetProductivityStats = "GetProductivityStats"
+
+	EndpointNameGetArchivedProjects = "GetArchivedProjects"
+	EndpointNameGetArchivedSections = "GetArchivedSections"
+
+	EndpointNameExportTemplate = "ExportTemplate"
+	EndpointNameImportTemplate = "ImportTemplate"
 )
 
 // the endpoints that we implement are stored here for easier reference in the actual calls
@@ -155,6 +384,13 @@ var endpoints = map[string]endpoint{
 		},
 		Method: http.MethodDelete,
 	},
+	EndpointNameGetAllCollaborators: {
+		Path: "/projects/:id/collaborators",
+		PathParams: map[string]string{
+			"id": "The project id",
+		},
+		Method: http.MethodGet,
+	},
 
 	// tasks
 	EndpointNameGetAllActiveTasks: {
@@ -270,6 +506,104 @@ var endpoints = map[string]endpoint{
 		},
 		Method: http.MethodDelete,
 	},
+
+	// comments
+
+	EndpointNameGetAllComments: {
+		Path:       "/comments",
+		PathParams: map[string]string{},
+		Method:     http.MethodGet,
+	},
+	EndpointNameCreateComment: {
+		Path:       "/comments",
+		PathParams: map[string]string{},
+		Method:     http.MethodPost,
+	},
+	EndpointNameGetComment: {
+		Path: "/comments/:id",
+		PathParams: map[string]string{
+			"id": "The comment id",
+		},
+		Method: http.MethodGet,
+	},
+	EndpointNameUpdateComment: {
+		Path: "/comments/:id",
+		PathParams: map[string]string{
+			"id": "The comment id",
+		},
+		Method: http.MethodPost,
+	},
+	EndpointNameDeleteComment: {
+		Path: "/comments/:id",
+		PathParams: map[string]string{
+			"id": "The comment id",
+		},
+		Method: http.MethodDelete,
+	},
+	// sync
+
+	EndpointNameSync: {
+		Path:       "/sync",
+		PathParams: map[string]string{},
+		Method:     http.MethodPost,
+		Sync:       true,
+		Form:       true,
+		// reads are safe to repeat, and Todoist discards commands whose uuid it has already seen
+		Idempotent: true,
+	},
+	EndpointNameQuickAddTask: {
+		Path:       "/quick/add",
+		PathParams: map[string]string{},
+		Method:     http.MethodPost,
+		Sync:       true,
+		Form:       true,
+	},
+
+	EndpointNameGetCompletedTasks: {
+		Path:       "/completed/get_all",
+		PathParams: map[string]string{},
+		Method:     http.MethodGet,
+		Sync:       true,
+	},
+	EndpointNameGetActivity: {
+		Path:       "/activity/get",
+		PathParams: map[string]string{},
+		Method:     http.MethodGet,
+		Sync:       true,
+	},
+	EndpointNameGetProductivityStats: {
+		Path:       "/completed/get_stats",
+		PathParams: map[string]string{},
+		Method:     http.MethodGet,
+		Sync:       true,
+	},
+
+	EndpointNameGetArchivedProjects: {
+		Path:       "/projects/get_archived",
+		PathParams: map[string]string{},
+		Method:     http.MethodGet,
+		Sync:       true,
+	},
+	EndpointNameGetArchivedSections: {
+		Path:       "/sections/get_archived",
+		PathParams: map[string]string{},
+		Method:     http.MethodGet,
+		Sync:       true,
+	},
+
+	EndpointNameExportTemplate: {
+		Path:       "/templates/export_as_file",
+		PathParams: map[string]string{},
+		Method:     http.MethodGet,
+		Sync:       true,
+	},
+	EndpointNameImportTemplate: {
+		Path:       "/templates/import_into_project",
+		PathParams: map[string]string{},
+		Method:     http.MethodPost,
+		Sync:       true,
+		Multipart:  true,
+	},
 }
 
 func Int64(in int64) *int64 {
@@ -283,6 +617,17 @@ func Int64Value(in *int64) int64 {
 	return 0
 }
 
+func Float64(in float64) *float64 {
+	return &in
+}
+
+func Float64Value(in *float64) float64 {
+	if in != nil {
+		return *in
+	}
+	return 0
+}
+
 func Bool(in bool) *bool {
 	return &in
 }
@@ -1,7 +1,12 @@
 package todoist
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -24,7 +29,69 @@ func TestClientPointerHelpers(t *testing.T) {
 }
 
 func TestNoEndpointCall(t *testing.T) {
-	resp, err := makeCall("test", "EndpointNameDoesNotExist", map[string]string{}, nil)
+	resp, err := NewClient("test").makeCall(context.Background(), "EndpointNameDoesNotExist", map[string]string{}, nil)
 	assert.NotNil(t, err)
 	assert.Zero(t, len(resp.Body))
 }
+
+func TestNewClient(t *testing.T) {
+	httpClient := &http.Client{Timeout: time.Second}
+	client := NewClient("platypus", WithHTTPClient(httpClient))
+	assert.Equal(t, "platypus", client.Token())
+	assert.Equal(t, httpClient, client.httpClient)
+	assert.Equal(t, defaultBaseURL, client.BaseURL())
+	assert.Equal(t, defaultAPIVersion, client.APIVersion())
+	assert.Equal(t, "https://api.todoist.com/rest/v1/tasks/42/close", client.endpointURL(endpoints[EndpointNameCloseTask], map[string]string{"id": "42"}))
+
+	// the copy should not leak its token back into the original
+	copied := client.withToken("echidna")
+	assert.Equal(t, "echidna", copied.Token())
+	assert.Equal(t, "platypus", client.Token())
+	assert.Equal(t, client.rest, copied.rest)
+
+	client = NewClient("platypus", WithBaseURL("http://localhost:8080/"), WithAPIVersion("/v2/"))
+	assert.Equal(t, "http://localhost:8080", client.BaseURL())
+	assert.Equal(t, "v2", client.APIVersion())
+	assert.Equal(t, "http://localhost:8080/rest/v2/projects", client.endpointURL(endpoints[EndpointNameGetProjects], nil))
+
+	// a nil HTTP client is ignored
+	client = NewClient("platypus", WithHTTPClient(nil))
+	assert.NotNil(t, client.httpClient)
+}
+
+func TestClientForToken(t *testing.T) {
+	setup()
+	existingToken := config.AuthToken
+	defer func() {
+		config.AuthToken = existingToken
+	}()
+	config.AuthToken = "from_env"
+	assert.Equal(t, "from_env", clientForToken("").Token())
+	assert.Equal(t, "explicit", clientForToken("explicit").Token())
+}
+
+func TestContextCancellation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		// hang until the caller gives up
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+	client := NewClient("test", WithBaseURL(server.URL))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	projects, err := client.GetAllProjectsWithContext(ctx)
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Zero(t, len(projects))
+
+	// the update succeeds, but the follow-up get must still honor the deadline
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	updated, err := client.UpdateTaskWithContext(ctx, 1, &TaskParams{Content: String("platypus")})
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Nil(t, updated)
+}
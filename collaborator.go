@@ -0,0 +1,59 @@
+package todoist
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Collaborator is a person a shared project is shared with. The ids match the Assignee and Assigner fields of a Task.
+type Collaborator struct {
+	ID    int64  `json:"id" db:"id"`
+	Name  string `json:"name" db:"name"`
+	Email string `json:"email" db:"email"`
+}
+
+// GetAllCollaborators returns all of the collaborators of a shared project. https://developer.todoist.com/rest/v1/#get-all-collaborators
+func GetAllCollaborators(token string, projectID int64) ([]Collaborator, error) {
+	return clientForToken(token).GetAllCollaborators(projectID)
+}
+
+// GetAllCollaboratorsWithContext is GetAllCollaborators with a context that can cancel the call or set its deadline
+func GetAllCollaboratorsWithContext(ctx context.Context, token string, projectID int64) ([]Collaborator, error) {
+	return clientForToken(token).GetAllCollaboratorsWithContext(ctx, projectID)
+}
+
+// GetAllCollaborators returns all of the collaborators of a shared project. https://developer.todoist.com/rest/v1/#get-all-collaborators
+func (c *Client) GetAllCollaborators(projectID int64) ([]Collaborator, error) {
+	return c.GetAllCollaboratorsWithContext(context.Background(), projectID)
+}
+
+// GetAllCollaboratorsWithContext is GetAllCollaborators with a context that can cancel the call or set its deadline
+func (c *Client) GetAllCollaboratorsWithContext(ctx context.Context, projectID int64) ([]Collaborator, error) {
+	collaborators := []Collaborator{}
+	resp, err := c.makeCall(ctx, EndpointNameGetAllCollaborators, map[string]string{
+		"id": fmt.Sprintf("%d", projectID),
+	}, nil)
+	if err != nil {
+		return collaborators, err
+	}
+	err = json.Unmarshal(resp.Body, &collaborators)
+	return collaborators, err
+}
+
+// ResolveTaskCollaborators finds the task's assignee and assigner in a list of collaborators, usually the result of GetAllCollaborators
+// for the task's project. Either is nil if the task does not have one or if it is not in the list.
+func ResolveTaskCollaborators(task *Task, collaborators []Collaborator) (assignee *Collaborator, assigner *Collaborator) {
+	if task == nil {
+		return nil, nil
+	}
+	for i := range collaborators {
+		if task.Assignee != 0 && collaborators[i].ID == task.Assignee {
+			assignee = &collaborators[i]
+		}
+		if task.Assigner != 0 && collaborators[i].ID == task.Assigner {
+			assigner = &collaborators[i]
+		}
+	}
+	return assignee, assigner
+}
@@ -0,0 +1,65 @@
+package todoist
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAllCollaborators(t *testing.T) {
+	setup()
+	tokenToUse := "" // if you didn't want to pass it to the env for a one off test, set this (but don't commit it!)
+	if tokenToUse == "" {
+		tokenToUse = config.AuthToken
+	}
+	existingToken := config.AuthToken
+	config.AuthToken = ""
+	collaborators, err := GetAllCollaborators("", 1)
+	assert.NotNil(t, err)
+	assert.Zero(t, len(collaborators))
+	assert.Equal(t, "Empty token", err.Error())
+	config.AuthToken = existingToken
+	if tokenToUse == "" {
+		// all further tests will fail, so we return from here
+		fmt.Println("Skipping the rest of the Collaborator tests")
+		return
+	}
+
+	project, err := CreateTestProject(tokenToUse)
+	assert.Nil(t, err)
+	require.NotNil(t, project)
+	defer DeleteProject(tokenToUse, project.ID)
+
+	collaborators, err = GetAllCollaborators(tokenToUse, -1)
+	assert.NotNil(t, err)
+	assert.Zero(t, len(collaborators))
+	collaborators, err = GetAllCollaborators(tokenToUse, project.ID)
+	assert.Nil(t, err)
+	for _, collaborator := range collaborators {
+		assert.NotZero(t, collaborator.ID)
+		assert.NotEqual(t, "", collaborator.Email)
+	}
+}
+
+func TestResolveTaskCollaborators(t *testing.T) {
+	collaborators := []Collaborator{
+		{ID: 1, Name: "Ada Lovelace", Email: "ada@example.com"},
+		{ID: 2, Name: "Grace Hopper", Email: "grace@example.com"},
+	}
+
+	assignee, assigner := ResolveTaskCollaborators(&Task{Assignee: 2, Assigner: 1}, collaborators)
+	require.NotNil(t, assignee)
+	require.NotNil(t, assigner)
+	assert.Equal(t, "Grace Hopper", assignee.Name)
+	assert.Equal(t, "Ada Lovelace", assigner.Name)
+
+	// unassigned, or assigned to someone no longer on the project
+	assignee, assigner = ResolveTaskCollaborators(&Task{Assignee: 3}, collaborators)
+	assert.Nil(t, assignee)
+	assert.Nil(t, assigner)
+	assignee, assigner = ResolveTaskCollaborators(nil, collaborators)
+	assert.Nil(t, assignee)
+	assert.Nil(t, assigner)
+}
@@ -0,0 +1,661 @@
+package todoist
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxCommandsPerRequest is the most commands the Sync API accepts in one request
+const maxCommandsPerRequest = 100
+
+// Ref refers to an object in a command: either an existing object by its id, or an object created earlier in the same batch by its
+// temp id. The zero Ref refers to nothing and leaves the field out of the command.
+type Ref struct {
+	id     int64
+	tempID string
+}
+
+// IDRef refers to an existing object
+func IDRef(id int64) Ref {
+	return Ref{id: id}
+}
+
+// ID returns the id of the object, which is zero for an object that has not been created yet
+func (r Ref) ID() int64 {
+	return r.id
+}
+
+// TempID returns the temp id of an object created in a batch
+func (r Ref) TempID() string {
+	return r.tempID
+}
+
+// IsZero reports whether the Ref refers to nothing
+func (r Ref) IsZero() bool {
+	return r.id == 0 && r.tempID == ""
+}
+
+// MarshalJSON encodes the Ref as the Sync API expects: a temp id as a string, or an id as a number
+func (r Ref) MarshalJSON() ([]byte, error) {
+	if r.tempID != "" {
+		return json.Marshal(r.tempID)
+	}
+	return json.Marshal(r.id)
+}
+
+// Command is a single write for the Sync API. https://developer.todoist.com/sync/v8/#write-resources
+type Command struct {
+	Type   string                 `json:"type"`
+	UUID   string                 `json:"uuid"`
+	TempID string                 `json:"temp_id,omitempty"`
+	Args   map[string]interface{} `json:"args"`
+}
+
+// Ref refers to the object the command creates, so later commands in the batch can use it before it has an id
+func (c *Command) Ref() Ref {
+	return Ref{tempID: c.TempID}
+}
+
+// Placement says where a task goes. Set at most one of Parent, Section, and Project when moving; all may be set when creating.
+type Placement struct {
+	Project Ref
+	Section Ref
+	Parent  Ref
+}
+
+// CommandBatch queues commands to send to the Sync API together. Creating and then filling a project with sections and tasks takes a
+// single request instead of one per object.
+type CommandBatch struct {
+	commands []*Command
+	// err is the first problem with the params of a queued command, which keeps the batch from being sent
+	err error
+}
+
+// check records the first problem found with the params of a queued command
+func (b *CommandBatch) check(err error) {
+	if b.err == nil && err != nil {
+		b.err = fmt.Errorf("command %d: %w", len(b.commands)+1, err)
+	}
+}
+
+// NewCommandBatch creates an empty batch
+func NewCommandBatch() *CommandBatch {
+	return &CommandBatch{}
+}
+
+// Commands returns the queued commands in order
+func (b *CommandBatch) Commands() []*Command {
+	return b.commands
+}
+
+// Len returns the number of queued commands
+func (b *CommandBatch) Len() int {
+	return len(b.commands)
+}
+
+// Add queues a command of any type, for commands without a typed helper. Values in the args may be Refs.
+func (b *CommandBatch) Add(commandType string, args map[string]interface{}) *Command {
+	cmd := &Command{
+		Type: commandType,
+		UUID: newUUID(),
+		Args: args,
+	}
+	b.commands = append(b.commands, cmd)
+	return cmd
+}
+
+// addCreate queues a command that creates an object, which gets a temp id
+func (b *CommandBatch) addCreate(commandType string, args map[string]interface{}) *Command {
+	cmd := b.Add(commandType, args)
+	cmd.TempID = newUUID()
+	return cmd
+}
+
+// CreateProject queues a project_add. The parent, if not zero, takes precedence over params.ParentID.
+func (b *CommandBatch) CreateProject(params *ProjectParams, parent Ref) *Command {
+	args := projectCommandArgs(params)
+	setRef(args, "parent_id", parent)
+	return b.addCreate("project_add", args)
+}
+
+// UpdateProject queues a project_update
+func (b *CommandBatch) UpdateProject(project Ref, params *ProjectParams) *Command {
+	args := projectCommandArgs(params)
+	delete(args, "parent_id")
+	args["id"] = project
+	return b.Add("project_update", args)
+}
+
+// DeleteProject queues a project_delete, which also deletes the project's children
+func (b *CommandBatch) DeleteProject(project Ref) *Command {
+	return b.Add("project_delete", map[string]interface{}{"id": project})
+}
+
+// ArchiveProject queues a project_archive, which also archives the project's subprojects
+func (b *CommandBatch) ArchiveProject(project Ref) *Command {
+	return b.Add("project_archive", map[string]interface{}{"id": project})
+}
+
+// UnarchiveProject queues a project_unarchive
+func (b *CommandBatch) UnarchiveProject(project Ref) *Command {
+	return b.Add("project_unarchive", map[string]interface{}{"id": project})
+}
+
+// CreateSection queues a section_add. The project, if not zero, takes precedence over params.ProjectID.
+func (b *CommandBatch) CreateSection(params *SectionParams, project Ref) *Command {
+	args := map[string]interface{}{}
+	if params != nil {
+		args["name"] = params.Name
+		setInt64(args, "project_id", params.ProjectID)
+		setInt64(args, "section_order", params.Order)
+	}
+	setRef(args, "project_id", project)
+	return b.addCreate("section_add", args)
+}
+
+// UpdateSection queues a section_update; only the name may change
+func (b *CommandBatch) UpdateSection(section Ref, params *SectionParams) *Command {
+	args := map[string]interface{}{"id": section}
+	if params != nil {
+		args["name"] = params.Name
+	}
+	return b.Add("section_update", args)
+}
+
+// MoveSection queues a section_move to another project
+func (b *CommandBatch) MoveSection(section Ref, project Ref) *Command {
+	return b.Add("section_move", map[string]interface{}{"id": section, "project_id": project})
+}
+
+// DeleteSection queues a section_delete, which also deletes the section's tasks
+func (b *CommandBatch) DeleteSection(section Ref) *Command {
+	return b.Add("section_delete", map[string]interface{}{"id": section})
+}
+
+// ArchiveSection queues a section_archive, which also completes the section's tasks
+func (b *CommandBatch) ArchiveSection(section Ref) *Command {
+	return b.Add("section_archive", map[string]interface{}{"id": section})
+}
+
+// UnarchiveSection queues a section_unarchive
+func (b *CommandBatch) UnarchiveSection(section Ref) *Command {
+	return b.Add("section_unarchive", map[string]interface{}{"id": section})
+}
+
+// CreateTask queues an item_add. The refs of the placement, if not zero, take precedence over the ids in the params.
+func (b *CommandBatch) CreateTask(params *TaskParams, placement Placement) *Command {
+	b.check(params.checkDue())
+	args := taskCommandArgs(params)
+	setRef(args, "project_id", placement.Project)
+	setRef(args, "section_id", placement.Section)
+	setRef(args, "parent_id", placement.Parent)
+	return b.addCreate("item_add", args)
+}
+
+// UpdateTask queues an item_update. The project, section, and parent cannot change this way; use MoveTask.
+func (b *CommandBatch) UpdateTask(task Ref, params *TaskParams) *Command {
+	b.check(params.checkDue())
+	args := taskCommandArgs(params)
+	delete(args, "project_id")
+	delete(args, "section_id")
+	delete(args, "parent_id")
+	args["id"] = task
+	return b.Add("item_update", args)
+}
+
+// MoveTask queues an item_move, which takes the task's subtasks along. Exactly one ref of the placement should be set.
+func (b *CommandBatch) MoveTask(task Ref, placement Placement) *Command {
+	args := map[string]interface{}{"id": task}
+	setRef(args, "parent_id", placement.Parent)
+	setRef(args, "section_id", placement.Section)
+	setRef(args, "project_id", placement.Project)
+	return b.Add("item_move", args)
+}
+
+// CloseTask queues an item_close, which completes the task the way the app's checkbox does
+func (b *CommandBatch) CloseTask(task Ref) *Command {
+	return b.Add("item_close", map[string]interface{}{"id": task})
+}
+
+// DeleteTask queues an item_delete, which also deletes the task's subtasks
+func (b *CommandBatch) DeleteTask(task Ref) *Command {
+	return b.Add("item_delete", map[string]interface{}{"id": task})
+}
+
+// CreateLabel queues a label_add
+func (b *CommandBatch) CreateLabel(params *LabelParams) *Command {
+	return b.addCreate("label_add", labelCommandArgs(params))
+}
+
+// UpdateLabel queues a label_update
+func (b *CommandBatch) UpdateLabel(label Ref, params *LabelParams) *Command {
+	args := labelCommandArgs(params)
+	args["id"] = label
+	return b.Add("label_update", args)
+}
+
+// DeleteLabel queues a label_delete
+func (b *CommandBatch) DeleteLabel(label Ref) *Command {
+	return b.Add("label_delete", map[string]interface{}{"id": label})
+}
+
+// CreateReminder queues a reminder_add for the task. The task of a relative reminder must be due at a time; see ReminderParams.ValidateFor.
+func (b *CommandBatch) CreateReminder(task Ref, params *ReminderParams) *Command {
+	args := reminderCommandArgs(params)
+	args["item_id"] = task
+	return b.addCreate("reminder_add", args)
+}
+
+// UpdateReminder queues a reminder_update
+func (b *CommandBatch) UpdateReminder(reminder Ref, params *ReminderParams) *Command {
+	args := reminderCommandArgs(params)
+	args["id"] = reminder
+	return b.Add("reminder_update", args)
+}
+
+// DeleteReminder queues a reminder_delete
+func (b *CommandBatch) DeleteReminder(reminder Ref) *Command {
+	return b.Add("reminder_delete", map[string]interface{}{"id": reminder})
+}
+
+// CreateFilter queues a filter_add
+func (b *CommandBatch) CreateFilter(params *FilterParams) *Command {
+	return b.addCreate("filter_add", filterCommandArgs(params))
+}
+
+// UpdateFilter queues a filter_update
+func (b *CommandBatch) UpdateFilter(filter Ref, params *FilterParams) *Command {
+	args := filterCommandArgs(params)
+	args["id"] = filter
+	return b.Add("filter_update", args)
+}
+
+// DeleteFilter queues a filter_delete
+func (b *CommandBatch) DeleteFilter(filter Ref) *Command {
+	return b.Add("filter_delete", map[string]interface{}{"id": filter})
+}
+
+// ReorderFilters queues a filter_update_orders that puts the filters in the order of the ids. Filters left out keep their order.
+func (b *CommandBatch) ReorderFilters(filterIDs []int64) *Command {
+	return b.Add("filter_update_orders", map[string]interface{}{"id_order_mapping": orderMapping(filterIDs)})
+}
+
+// ReorderTasks queues an item_reorder that puts sibling tasks in the order of the ids
+func (b *CommandBatch) ReorderTasks(taskIDs []int64) *Command {
+	return b.Add("item_reorder", map[string]interface{}{"items": orderedItems(taskIDs, "child_order")})
+}
+
+// ReorderTodayTasks queues an item_update_day_orders that puts tasks in the order of the ids in the Today view
+func (b *CommandBatch) ReorderTodayTasks(taskIDs []int64) *Command {
+	return b.Add("item_update_day_orders", map[string]interface{}{"ids_to_orders": orderMapping(taskIDs)})
+}
+
+// ReorderSections queues a section_reorder that puts the sections of a project in the order of the ids
+func (b *CommandBatch) ReorderSections(sectionIDs []int64) *Command {
+	return b.Add("section_reorder", map[string]interface{}{"sections": orderedItems(sectionIDs, "section_order")})
+}
+
+// MoveProject queues a project_move under the parent, or to the top level if the parent is zero
+func (b *CommandBatch) MoveProject(project Ref, parent Ref) *Command {
+	args := map[string]interface{}{"id": project, "parent_id": nil}
+	setRef(args, "parent_id", parent)
+	return b.Add("project_move", args)
+}
+
+// ReorderProjects queues a project_reorder that puts sibling projects in the order of the ids
+func (b *CommandBatch) ReorderProjects(projectIDs []int64) *Command {
+	return b.Add("project_reorder", map[string]interface{}{"projects": orderedItems(projectIDs, "child_order")})
+}
+
+// ReorderLabels queues a label_update_orders that puts the labels in the order of the ids. Labels left out keep their order.
+func (b *CommandBatch) ReorderLabels(labelIDs []int64) *Command {
+	return b.Add("label_update_orders", map[string]interface{}{"id_order_mapping": orderMapping(labelIDs)})
+}
+
+// projectCommandArgs converts the params to the argument names of the Sync API
+func projectCommandArgs(params *ProjectParams) map[string]interface{} {
+	args := map[string]interface{}{}
+	if params == nil {
+		return args
+	}
+	setString(args, "name", params.Name)
+	setInt64(args, "parent_id", params.ParentID)
+	if params.Color != 0 {
+		args["color"] = params.Color
+	}
+	setBool(args, "is_favorite", params.Favorite)
+	return args
+}
+
+// taskCommandArgs converts the params to the argument names of the Sync API, where the due fields become a due object
+func taskCommandArgs(params *TaskParams) map[string]interface{} {
+	args := map[string]interface{}{}
+	if params == nil {
+		return args
+	}
+	setString(args, "content", params.Content)
+	setString(args, "description", params.Description)
+	setInt64(args, "project_id", params.ProjectID)
+	setInt64(args, "section_id", params.SectionID)
+	setInt64(args, "parent_id", params.ParentID)
+	setInt64(args, "child_order", params.Order)
+	setInt64(args, "responsible_uid", params.Assignee)
+	if params.LabelIDs != nil {
+		args["labels"] = *params.LabelIDs
+	}
+	if params.Priority != 0 {
+		args["priority"] = params.Priority
+	}
+	due := map[string]interface{}{}
+	setString(due, "string", params.DueString)
+	setString(due, "lang", params.DueLang)
+	setString(due, "date", params.DueDate)
+	setString(due, "date", params.DueDatetime)
+	if len(due) > 0 {
+		args["due"] = due
+	}
+	return args
+}
+
+// labelCommandArgs converts the params to the argument names of the Sync API
+func labelCommandArgs(params *LabelParams) map[string]interface{} {
+	args := map[string]interface{}{}
+	if params == nil {
+		return args
+	}
+	if params.Name != "" {
+		args["name"] = params.Name
+	}
+	setInt64(args, "color", params.Color)
+	setInt64(args, "item_order", params.Order)
+	setBool(args, "is_favorite", params.Favorite)
+	return args
+}
+
+// reminderCommandArgs converts the params to the argument names of the Sync API, where the due fields become a due object and the
+// coordinates strings
+func reminderCommandArgs(params *ReminderParams) map[string]interface{} {
+	args := map[string]interface{}{}
+	if params == nil {
+		return args
+	}
+	if params.Type != "" {
+		args["type"] = params.Type
+	}
+	setInt64(args, "notify_uid", params.NotifyUID)
+	setInt64(args, "minute_offset", params.MinuteOffset)
+	due := map[string]interface{}{}
+	setString(due, "string", params.DueString)
+	setString(due, "lang", params.DueLang)
+	setString(due, "date", params.DueDatetime)
+	if len(due) > 0 {
+		args["due"] = due
+	}
+	setString(args, "name", params.Name)
+	if params.Latitude != nil {
+		args["loc_lat"] = strconv.FormatFloat(*params.Latitude, 'f', -1, 64)
+	}
+	if params.Longitude != nil {
+		args["loc_long"] = strconv.FormatFloat(*params.Longitude, 'f', -1, 64)
+	}
+	setInt64(args, "radius", params.Radius)
+	if params.Trigger != "" {
+		args["loc_trigger"] = params.Trigger
+	}
+	return args
+}
+
+// filterCommandArgs converts the params to the argument names of the Sync API
+func filterCommandArgs(params *FilterParams) map[string]interface{} {
+	args := map[string]interface{}{}
+	if params == nil {
+		return args
+	}
+	setString(args, "name", params.Name)
+	setString(args, "query", params.Query)
+	if params.Color != 0 {
+		args["color"] = params.Color
+	}
+	setInt64(args, "item_order", params.Order)
+	setBool(args, "is_favorite", params.Favorite)
+	return args
+}
+
+// orderMapping numbers the ids from 1 in the order given, keyed the way the Sync API's id_order_mapping expects
+func orderMapping(ids []int64) map[string]int64 {
+	mapping := make(map[string]int64, len(ids))
+	for i, id := range ids {
+		mapping[strconv.FormatInt(id, 10)] = int64(i + 1)
+	}
+	return mapping
+}
+
+// orderedItems numbers the ids from 1 in the order given, as the list of objects the Sync API's reorder commands expect
+func orderedItems(ids []int64, orderKey string) []map[string]interface{} {
+	items := make([]map[string]interface{}, len(ids))
+	for i, id := range ids {
+		items[i] = map[string]interface{}{"id": id, orderKey: int64(i + 1)}
+	}
+	return items
+}
+
+func setRef(args map[string]interface{}, key string, ref Ref) {
+	if !ref.IsZero() {
+		args[key] = ref
+	}
+}
+
+func setString(args map[string]interface{}, key string, value *string) {
+	if value != nil {
+		args[key] = *value
+	}
+}
+
+func setInt64(args map[string]interface{}, key string, value *int64) {
+	if value != nil {
+		args[key] = *value
+	}
+}
+
+func setBool(args map[string]interface{}, key string, value *bool) {
+	if value != nil {
+		args[key] = *value
+	}
+}
+
+// CommandError is the reason the Sync API rejected a command
+type CommandError struct {
+	Code     int                    `json:"error_code"`
+	Message  string                 `json:"error"`
+	HTTPCode int                    `json:"http_code"`
+	Extra    map[string]interface{} `json:"error_extra"`
+}
+
+func (e *CommandError) Error() string {
+	return fmt.Sprintf("todoist: command failed with error %d: %s", e.Code, e.Message)
+}
+
+// BatchError is returned when some of the commands in a batch failed. The commands not listed succeeded.
+type BatchError struct {
+	Failures map[string]*CommandError // by command uuid
+}
+
+func (e *BatchError) Error() string {
+	uuids := make([]string, 0, len(e.Failures))
+	for uuid := range e.Failures {
+		uuids = append(uuids, uuid)
+	}
+	sort.Strings(uuids)
+	messages := make([]string, len(uuids))
+	for i, uuid := range uuids {
+		messages[i] = fmt.Sprintf("%s: %s", uuid, e.Failures[uuid].Message)
+	}
+	return fmt.Sprintf("todoist: %d command(s) failed: %s", len(uuids), strings.Join(messages, "; "))
+}
+
+// BatchResult reports the outcome of every command in a batch, and the ids of the objects it created
+type BatchResult struct {
+	SyncStatus    map[string]*CommandError // by command uuid; nil for the commands that succeeded
+	TempIDMapping map[string]int64         // the id of each object created, by temp id
+}
+
+// Err returns the error of the command, or nil if it succeeded
+func (r *BatchResult) Err(cmd *Command) error {
+	if failure := r.SyncStatus[cmd.UUID]; failure != nil {
+		return failure
+	}
+	if _, found := r.SyncStatus[cmd.UUID]; !found {
+		return fmt.Errorf("no status for command %s", cmd.UUID)
+	}
+	return nil
+}
+
+// ID returns the id the ref ended up with: the id of an existing object, or the id created for a temp id
+func (r *BatchResult) ID(ref Ref) (int64, bool) {
+	if ref.tempID == "" {
+		return ref.id, ref.id != 0
+	}
+	id, found := r.TempIDMapping[ref.tempID]
+	return id, found
+}
+
+// commandResponse is the part of a /sync response that reports on commands
+type commandResponse struct {
+	SyncStatus    map[string]json.RawMessage `json:"sync_status"`
+	TempIDMapping map[string]int64           `json:"temp_id_mapping"`
+}
+
+// ExecuteBatch sends the batch to the Sync API. Batches of more than 100 commands are sent in chunks, with refs to objects created in
+// an earlier chunk replaced by their new ids. If any command fails, the result is returned along with a *BatchError listing the failures.
+func (c *Client) ExecuteBatch(batch *CommandBatch) (*BatchResult, error) {
+	return c.ExecuteBatchWithContext(context.Background(), batch)
+}
+
+// ExecuteBatchWithContext is ExecuteBatch with a context that can cancel the call or set its deadline
+func (c *Client) ExecuteBatchWithContext(ctx context.Context, batch *CommandBatch) (*BatchResult, error) {
+	if batch == nil || batch.Len() == 0 {
+		return nil, errors.New("the batch has no commands")
+	}
+	if batch.err != nil {
+		return nil, batch.err
+	}
+	result := &BatchResult{
+		SyncStatus:    map[string]*CommandError{},
+		TempIDMapping: map[string]int64{},
+	}
+	for start := 0; start < batch.Len(); start += maxCommandsPerRequest {
+		end := start + maxCommandsPerRequest
+		if end > batch.Len() {
+			end = batch.Len()
+		}
+		if err := c.sendCommands(ctx, batch.commands[start:end], result); err != nil {
+			return result, err
+		}
+	}
+
+	failures := map[string]*CommandError{}
+	for uuid, failure := range result.SyncStatus {
+		if failure != nil {
+			failures[uuid] = failure
+		}
+	}
+	if len(failures) > 0 {
+		return result, &BatchError{Failures: failures}
+	}
+	return result, nil
+}
+
+// executeCommand sends a batch of the single command, returning the command's own error if it failed
+func (c *Client) executeCommand(ctx context.Context, batch *CommandBatch, cmd *Command) (*BatchResult, error) {
+	result, err := c.ExecuteBatchWithContext(ctx, batch)
+	batchErr := &BatchError{}
+	if errors.As(err, &batchErr) {
+		return result, result.Err(cmd)
+	}
+	return result, err
+}
+
+// sendCommands sends one request worth of commands, recording their outcome in the result
+func (c *Client) sendCommands(ctx context.Context, commands []*Command, result *BatchResult) error {
+	resolved := make([]Command, len(commands))
+	for i, cmd := range commands {
+		resolved[i] = *cmd
+		resolved[i].Args = resolveRefs(cmd.Args, result.TempIDMapping).(map[string]interface{})
+	}
+	encoded, err := json.Marshal(resolved)
+	if err != nil {
+		return err
+	}
+	resp, err := c.makeCall(ctx, EndpointNameSync, map[string]string{}, map[string]string{
+		"commands": string(encoded),
+	})
+	if err != nil {
+		return err
+	}
+	decoded := &commandResponse{}
+	if err = json.Unmarshal(resp.Body, decoded); err != nil {
+		return err
+	}
+	for tempID, id := range decoded.TempIDMapping {
+		result.TempIDMapping[tempID] = id
+	}
+	for uuid, status := range decoded.SyncStatus {
+		if string(status) == `"ok"` {
+			result.SyncStatus[uuid] = nil
+			continue
+		}
+		failure := &CommandError{}
+		if err = json.Unmarshal(status, failure); err != nil {
+			return fmt.Errorf("could not decode the status of command %s: %w", uuid, err)
+		}
+		result.SyncStatus[uuid] = failure
+	}
+	return nil
+}
+
+// resolveRefs replaces the refs to objects that already have an id, walking into nested maps and slices
+func resolveRefs(value interface{}, mapping map[string]int64) interface{} {
+	switch v := value.(type) {
+	case Ref:
+		if id, found := mapping[v.tempID]; found {
+			return IDRef(id)
+		}
+		return v
+	case map[string]interface{}:
+		resolved := make(map[string]interface{}, len(v))
+		for k, inner := range v {
+			resolved[k] = resolveRefs(inner, mapping)
+		}
+		return resolved
+	case []interface{}:
+		resolved := make([]interface{}, len(v))
+		for i, inner := range v {
+			resolved[i] = resolveRefs(inner, mapping)
+		}
+		return resolved
+	case []map[string]interface{}:
+		resolved := make([]map[string]interface{}, len(v))
+		for i, inner := range v {
+			resolved[i] = resolveRefs(inner, mapping).(map[string]interface{})
+		}
+		return resolved
+	}
+	return value
+}
+
+// newUUID returns a random, version 4 UUID for command uuids and temp ids
+func newUUID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("todoist: could not generate a uuid: %v", err))
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
@@ -0,0 +1,143 @@
+package todoist
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandBatchArgs(t *testing.T) {
+	batch := NewCommandBatch()
+	project := batch.CreateProject(&ProjectParams{Name: String("Client X"), Color: 41}, Ref{})
+	section := batch.CreateSection(&SectionParams{Name: "Backlog"}, project.Ref())
+	task := batch.CreateTask(&TaskParams{
+		Content:   String("Kickoff"),
+		Priority:  4,
+		LabelIDs:  &[]int64{7},
+		DueString: String("tomorrow"),
+		Assignee:  Int64(9),
+	}, Placement{Project: project.Ref(), Section: section.Ref()})
+	move := batch.MoveTask(task.Ref(), Placement{Project: IDRef(42)})
+	closed := batch.CloseTask(IDRef(43))
+	require.Equal(t, 5, batch.Len())
+
+	uuidPattern := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	seen := map[string]bool{}
+	for _, cmd := range batch.Commands() {
+		assert.Regexp(t, uuidPattern, cmd.UUID)
+		assert.False(t, seen[cmd.UUID])
+		seen[cmd.UUID] = true
+	}
+	assert.NotEmpty(t, project.TempID)
+	assert.Empty(t, move.TempID)
+
+	encoded, err := json.Marshal(batch.Commands())
+	require.Nil(t, err)
+	decoded := []map[string]interface{}{}
+	require.Nil(t, json.Unmarshal(encoded, &decoded))
+
+	assert.Equal(t, "project_add", decoded[0]["type"])
+	assert.Equal(t, map[string]interface{}{"name": "Client X", "color": float64(41)}, decoded[0]["args"])
+	assert.Equal(t, map[string]interface{}{"name": "Backlog", "project_id": project.TempID}, decoded[1]["args"])
+	assert.Equal(t, map[string]interface{}{
+		"content":         "Kickoff",
+		"priority":        float64(4),
+		"labels":          []interface{}{float64(7)},
+		"due":             map[string]interface{}{"string": "tomorrow"},
+		"responsible_uid": float64(9),
+		"project_id":      project.TempID,
+		"section_id":      section.TempID,
+	}, decoded[2]["args"])
+	assert.Equal(t, map[string]interface{}{"id": task.TempID, "project_id": float64(42)}, decoded[3]["args"])
+	assert.Equal(t, "item_close", closed.Type)
+	assert.Equal(t, map[string]interface{}{"id": float64(43)}, decoded[4]["args"])
+}
+
+func TestExecuteBatch(t *testing.T) {
+	fake, client := newFakeTodoistClient(t)
+
+	batch := NewCommandBatch()
+	project := batch.CreateProject(&ProjectParams{Name: String("Client X")}, Ref{})
+	task := batch.CreateTask(&TaskParams{Content: String("Kickoff")}, Placement{Project: project.Ref()})
+	failed := batch.CreateTask(&TaskParams{Content: String("")}, Placement{Project: project.Ref()})
+
+	result, err := client.ExecuteBatch(batch)
+	require.Len(t, fake.syncRequests, 1)
+	require.NotNil(t, result)
+
+	batchErr := &BatchError{}
+	require.True(t, errors.As(err, &batchErr))
+	assert.Len(t, batchErr.Failures, 1)
+	assert.Contains(t, err.Error(), "Invalid argument value")
+
+	assert.Nil(t, result.Err(project))
+	assert.Nil(t, result.Err(task))
+	commandErr := &CommandError{}
+	require.True(t, errors.As(result.Err(failed), &commandErr))
+	assert.Equal(t, 19, commandErr.Code)
+	assert.Equal(t, 400, commandErr.HTTPCode)
+	assert.Equal(t, "content", commandErr.Extra["argument"])
+
+	projectID, found := result.ID(project.Ref())
+	assert.True(t, found)
+	assert.Equal(t, int64(1001), projectID)
+	taskID, found := result.ID(task.Ref())
+	assert.True(t, found)
+	assert.Equal(t, projectID, fake.collections["tasks"][taskID]["project_id"])
+	_, found = result.ID(failed.Ref())
+	assert.False(t, found)
+	id, found := result.ID(IDRef(5))
+	assert.True(t, found)
+	assert.Equal(t, int64(5), id)
+}
+
+func TestExecuteBatchChunks(t *testing.T) {
+	fake, client := newFakeTodoistClient(t)
+
+	batch := NewCommandBatch()
+	project := batch.CreateProject(&ProjectParams{Name: String("Big")}, Ref{})
+	var last *Command
+	for i := 0; i < 120; i++ {
+		last = batch.CreateTask(&TaskParams{Content: String(fmt.Sprintf("Task %d", i))}, Placement{Project: project.Ref()})
+	}
+
+	result, err := client.ExecuteBatch(batch)
+	require.Nil(t, err)
+	requests := fake.syncRequests
+	require.Len(t, requests, 2)
+	assert.Len(t, requests[0], 100)
+	assert.Len(t, requests[1], 21)
+
+	// the second request refers to the project by the id it got in the first
+	assert.Equal(t, project.TempID, requests[0][1]["args"].(map[string]interface{})["project_id"])
+	assert.Equal(t, float64(1001), requests[1][0]["args"].(map[string]interface{})["project_id"])
+	assert.Len(t, result.TempIDMapping, 121)
+	assert.Nil(t, result.Err(last))
+}
+
+func TestExecuteBatchEmpty(t *testing.T) {
+	_, err := NewClient("commands-empty-test").ExecuteBatch(NewCommandBatch())
+	assert.NotNil(t, err)
+}
+
+func TestExecuteBatchInvalidParams(t *testing.T) {
+	fake, client := newFakeTodoistClient(t)
+
+	// a date and a datetime would both be sent as the date of the due, so the batch is refused before anything is sent
+	batch := NewCommandBatch()
+	batch.CreateTask(&TaskParams{Content: String("Kickoff"), DueDate: String("2021-06-01")}, Placement{})
+	batch.UpdateTask(IDRef(1), &TaskParams{DueDate: String("2021-06-01"), DueDatetime: String("2021-06-01T09:00:00Z")})
+	_, err := client.ExecuteBatch(batch)
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "command 2")
+	assert.Empty(t, fake.syncRequests)
+
+	_, err = client.CreateTask(&TaskParams{Content: String("Kickoff"), DueDate: String("2021-06-01"), DueDatetime: String("2021-06-01T09:00:00Z")})
+	assert.NotNil(t, err)
+	assert.Empty(t, fake.collections["tasks"])
+}
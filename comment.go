@@ -0,0 +1,209 @@
+package todoist
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Comment is a note left on either a task or a project
+type Comment struct {
+	ID         int64              `json:"id" db:"id"`
+	TaskID     int64              `json:"task_id,omitempty" db:"task_id"`
+	ProjectID  int64              `json:"project_id,omitempty" db:"project_id"`
+	Posted     string             `json:"posted" db:"posted"`
+	Content    string             `json:"content" db:"content"`
+	Attachment *CommentAttachment `json:"attachment,omitempty" db:"attachment"`
+}
+
+// CommentAttachment is a file or link attached to a comment. Which fields are set depends on the ResourceType, such as file, url, image,
+// or audio.
+type CommentAttachment struct {
+	ResourceType string `json:"resource_type,omitempty" db:"resource_type"`
+	FileName     string `json:"file_name,omitempty" db:"file_name"`
+	FileType     string `json:"file_type,omitempty" db:"file_type"`
+	FileURL      string `json:"file_url,omitempty" db:"file_url"`
+	FileSize     int64  `json:"file_size,omitempty" db:"file_size"`
+	FileDuration int64  `json:"file_duration,omitempty" db:"file_duration"`
+	UploadState  string `json:"upload_state,omitempty" db:"upload_state"`
+	Image        string `json:"image,omitempty" db:"image"`
+	ImageWidth   int64  `json:"image_width,omitempty" db:"image_width"`
+	ImageHeight  int64  `json:"image_height,omitempty" db:"image_height"`
+	URL          string `json:"url,omitempty" db:"url"`
+	Title        string `json:"title,omitempty" db:"title"`
+}
+
+// CommentParams are used when creating or updating a comment. A new comment needs content and exactly one of a task id or a project id;
+// an update may only change the content.
+type CommentParams struct {
+	TaskID     *int64             `json:"task_id,omitempty" db:"task_id"`
+	ProjectID  *int64             `json:"project_id,omitempty" db:"project_id"`
+	Content    string             `json:"content" db:"content"`
+	Attachment *CommentAttachment `json:"attachment,omitempty" db:"attachment"`
+}
+
+// GetAllComments returns all of the comments on either a task or a project, so exactly one of the ids must be non-zero. https://developer.todoist.com/rest/v1/#get-all-comments
+func GetAllComments(token string, taskID int64, projectID int64) ([]Comment, error) {
+	return clientForToken(token).GetAllComments(taskID, projectID)
+}
+
+// GetAllCommentsWithContext is GetAllComments with a context that can cancel the call or set its deadline
+func GetAllCommentsWithContext(ctx context.Context, token string, taskID int64, projectID int64) ([]Comment, error) {
+	return clientForToken(token).GetAllCommentsWithContext(ctx, taskID, projectID)
+}
+
+// CreateComment creates a comment on a task or a project and requires content. https://developer.todoist.com/rest/v1/#create-a-new-comment
+func CreateComment(token string, input *CommentParams) (*Comment, error) {
+	return clientForToken(token).CreateComment(input)
+}
+
+// CreateCommentWithContext is CreateComment with a context that can cancel the call or set its deadline
+func CreateCommentWithContext(ctx context.Context, token string, input *CommentParams) (*Comment, error) {
+	return clientForToken(token).CreateCommentWithContext(ctx, input)
+}
+
+// GetComment gets a single comment. https://developer.todoist.com/rest/v1/#get-a-comment
+func GetComment(token string, commentID int64) (*Comment, error) {
+	return clientForToken(token).GetComment(commentID)
+}
+
+// GetCommentWithContext is GetComment with a context that can cancel the call or set its deadline
+func GetCommentWithContext(ctx context.Context, token string, commentID int64) (*Comment, error) {
+	return clientForToken(token).GetCommentWithContext(ctx, commentID)
+}
+
+// UpdateComment updates the content of a comment. https://developer.todoist.com/rest/v1/#update-a-comment
+func UpdateComment(token string, commentID int64, input *CommentParams) (*Comment, error) {
+	return clientForToken(token).UpdateComment(commentID, input)
+}
+
+// UpdateCommentWithContext is UpdateComment with a context that can cancel the call or set its deadline
+func UpdateCommentWithContext(ctx context.Context, token string, commentID int64, input *CommentParams) (*Comment, error) {
+	return clientForToken(token).UpdateCommentWithContext(ctx, commentID, input)
+}
+
+// DeleteComment deletes a comment. https://developer.todoist.com/rest/v1/#delete-a-comment
+func DeleteComment(token string, commentID int64) error {
+	return clientForToken(token).DeleteComment(commentID)
+}
+
+// DeleteCommentWithContext is DeleteComment with a context that can cancel the call or set its deadline
+func DeleteCommentWithContext(ctx context.Context, token string, commentID int64) error {
+	return clientForToken(token).DeleteCommentWithContext(ctx, commentID)
+}
+
+// GetAllComments returns all of the comments on either a task or a project, so exactly one of the ids must be non-zero. https://developer.todoist.com/rest/v1/#get-all-comments
+func (c *Client) GetAllComments(taskID int64, projectID int64) ([]Comment, error) {
+	return c.GetAllCommentsWithContext(context.Background(), taskID, projectID)
+}
+
+// GetAllCommentsWithContext is GetAllComments with a context that can cancel the call or set its deadline
+func (c *Client) GetAllCommentsWithContext(ctx context.Context, taskID int64, projectID int64) ([]Comment, error) {
+	comments := []Comment{}
+	if (taskID == 0) == (projectID == 0) {
+		return comments, errors.New("exactly one of task_id and project_id is required")
+	}
+	data := map[string]string{}
+	if taskID != 0 {
+		data["task_id"] = fmt.Sprintf("%d", taskID)
+	} else {
+		data["project_id"] = fmt.Sprintf("%d", projectID)
+	}
+	resp, err := c.makeCall(ctx, EndpointNameGetAllComments, map[string]string{}, data)
+	if err != nil {
+		return comments, err
+	}
+	err = json.Unmarshal(resp.Body, &comments)
+	return comments, err
+}
+
+// CreateComment creates a comment on a task or a project and requires content. https://developer.todoist.com/rest/v1/#create-a-new-comment
+func (c *Client) CreateComment(input *CommentParams) (*Comment, error) {
+	return c.CreateCommentWithContext(context.Background(), input)
+}
+
+// CreateCommentWithContext is CreateComment with a context that can cancel the call or set its deadline
+func (c *Client) CreateCommentWithContext(ctx context.Context, input *CommentParams) (*Comment, error) {
+	if input == nil {
+		return nil, errors.New("you must provide a valid input with at least a content field and a task_id or project_id field")
+	}
+	if input.Content == "" {
+		return nil, errors.New("content is required")
+	}
+	if (Int64Value(input.TaskID) == 0) == (Int64Value(input.ProjectID) == 0) {
+		return nil, errors.New("exactly one of task_id and project_id is required")
+	}
+	resp, err := c.makeCall(ctx, EndpointNameCreateComment, map[string]string{}, input)
+	if err != nil {
+		return nil, err
+	}
+	created := &Comment{}
+	err = json.Unmarshal(resp.Body, &created)
+	return created, err
+}
+
+// GetComment gets a single comment. https://developer.todoist.com/rest/v1/#get-a-comment
+func (c *Client) GetComment(commentID int64) (*Comment, error) {
+	return c.GetCommentWithContext(context.Background(), commentID)
+}
+
+// GetCommentWithContext is GetComment with a context that can cancel the call or set its deadline
+func (c *Client) GetCommentWithContext(ctx context.Context, commentID int64) (*Comment, error) {
+	resp, err := c.makeCall(ctx, EndpointNameGetComment, map[string]string{
+		"id": fmt.Sprintf("%d", commentID),
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	found := &Comment{}
+	err = json.Unmarshal(resp.Body, &found)
+	return found, err
+}
+
+// UpdateComment updates the content of a comment. https://developer.todoist.com/rest/v1/#update-a-comment
+func (c *Client) UpdateComment(commentID int64, input *CommentParams) (*Comment, error) {
+	return c.UpdateCommentWithContext(context.Background(), commentID, input)
+}
+
+// UpdateCommentWithContext is UpdateComment with a context that can cancel the call or set its deadline
+func (c *Client) UpdateCommentWithContext(ctx context.Context, commentID int64, input *CommentParams) (*Comment, error) {
+	if input == nil {
+		return nil, errors.New("you must provide a valid input with at least a content field")
+	}
+	if input.Content == "" {
+		return nil, errors.New("content is required")
+	}
+	// only the content may change
+	_, err := c.makeCall(ctx, EndpointNameUpdateComment, map[string]string{
+		"id": fmt.Sprintf("%d", commentID),
+	}, map[string]string{
+		"content": input.Content,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// the update itself returns nothing, so we need to
+	// get it again if we want the updated information
+	return c.GetCommentWithContext(ctx, commentID)
+}
+
+// DeleteComment deletes a comment. https://developer.todoist.com/rest/v1/#delete-a-comment
+func (c *Client) DeleteComment(commentID int64) error {
+	return c.DeleteCommentWithContext(context.Background(), commentID)
+}
+
+// DeleteCommentWithContext is DeleteComment with a context that can cancel the call or set its deadline
+func (c *Client) DeleteCommentWithContext(ctx context.Context, commentID int64) error {
+	resp, err := c.makeCall(ctx, EndpointNameDeleteComment, map[string]string{
+		"id": fmt.Sprintf("%d", commentID),
+	}, nil)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("received status code %d", resp.StatusCode)
+	}
+	return nil
+}
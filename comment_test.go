@@ -0,0 +1,131 @@
+package todoist
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommentCRUD(t *testing.T) {
+	setup()
+	tokenToUse := "" // if you didn't want to pass it to the env for a one off test, set this (but don't commit it!)
+	if tokenToUse == "" {
+		tokenToUse = config.AuthToken
+	}
+	existingToken := config.AuthToken
+	config.AuthToken = ""
+	comments, err := GetAllComments("", 0, 1)
+	assert.NotNil(t, err)
+	assert.Zero(t, len(comments))
+	assert.Equal(t, "Empty token", err.Error())
+	config.AuthToken = existingToken
+	if tokenToUse == "" {
+		// all further tests will fail, so we return from here
+		fmt.Println("Skipping the rest of the Comment tests")
+		return
+	}
+
+	project, err := CreateTestProject(tokenToUse)
+	assert.Nil(t, err)
+	require.NotNil(t, project)
+	defer DeleteProject(tokenToUse, project.ID)
+	r := rand.Int63n(999999)
+	task, err := CreateTask(tokenToUse, &TaskParams{
+		Content:   String(fmt.Sprintf("Task for comments %d", r)),
+		ProjectID: Int64(project.ID),
+	})
+	assert.Nil(t, err)
+	require.NotNil(t, task)
+	defer DeleteTask(tokenToUse, task.ID)
+
+	// listing needs exactly one parent
+	comments, err = GetAllComments(tokenToUse, 0, 0)
+	assert.NotNil(t, err)
+	assert.Zero(t, len(comments))
+	comments, err = GetAllComments(tokenToUse, task.ID, project.ID)
+	assert.NotNil(t, err)
+	assert.Zero(t, len(comments))
+
+	// bad creates
+	created, err := CreateComment(tokenToUse, nil)
+	assert.Nil(t, created)
+	assert.NotNil(t, err)
+	params := &CommentParams{
+		TaskID: Int64(task.ID),
+	}
+	created, err = CreateComment(tokenToUse, params)
+	assert.Nil(t, created)
+	assert.NotNil(t, err)
+	params.Content = fmt.Sprintf("Comment %d", r)
+	params.ProjectID = Int64(project.ID)
+	created, err = CreateComment(tokenToUse, params)
+	assert.Nil(t, created)
+	assert.NotNil(t, err)
+
+	// one on the task, with an attachment, and one on the project
+	params.ProjectID = nil
+	params.Attachment = &CommentAttachment{
+		ResourceType: "file",
+		FileName:     "notes.pdf",
+		FileType:     "application/pdf",
+		FileURL:      "https://example.com/notes.pdf",
+	}
+	created, err = CreateComment(tokenToUse, params)
+	assert.Nil(t, err)
+	require.NotNil(t, created)
+	assert.NotZero(t, created.ID)
+	assert.Equal(t, task.ID, created.TaskID)
+	assert.Equal(t, params.Content, created.Content)
+	require.NotNil(t, created.Attachment)
+	assert.Equal(t, "notes.pdf", created.Attachment.FileName)
+	defer DeleteComment(tokenToUse, created.ID)
+
+	projectComment, err := CreateComment(tokenToUse, &CommentParams{
+		ProjectID: Int64(project.ID),
+		Content:   "On the project",
+	})
+	assert.Nil(t, err)
+	require.NotNil(t, projectComment)
+	assert.Equal(t, project.ID, projectComment.ProjectID)
+	defer DeleteComment(tokenToUse, projectComment.ID)
+
+	// get them in the lists and singularly
+	comments, err = GetAllComments(tokenToUse, task.ID, 0)
+	assert.Nil(t, err)
+	require.Len(t, comments, 1)
+	assert.Equal(t, created.ID, comments[0].ID)
+	comments, err = GetAllComments(tokenToUse, 0, project.ID)
+	assert.Nil(t, err)
+	require.Len(t, comments, 1)
+	assert.Equal(t, projectComment.ID, comments[0].ID)
+
+	found, err := GetComment(tokenToUse, created.ID)
+	assert.Nil(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, created.Content, found.Content)
+
+	// update it, make sure it sticks
+	updated, err := UpdateComment(tokenToUse, created.ID, nil)
+	assert.NotNil(t, err)
+	assert.Nil(t, updated)
+	updated, err = UpdateComment(tokenToUse, created.ID, &CommentParams{})
+	assert.NotNil(t, err)
+	assert.Nil(t, updated)
+	updated, err = UpdateComment(tokenToUse, created.ID, &CommentParams{
+		Content: "Updated comment",
+	})
+	assert.Nil(t, err)
+	require.NotNil(t, updated)
+	assert.Equal(t, "Updated comment", updated.Content)
+	assert.Equal(t, task.ID, updated.TaskID)
+
+	// delete it and make sure it is gone
+	err = DeleteComment(tokenToUse, created.ID)
+	assert.Nil(t, err)
+	shouldBeGone, err := GetComment(tokenToUse, created.ID)
+	assert.Nil(t, shouldBeGone)
+	assert.NotNil(t, err)
+}
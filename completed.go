@@ -0,0 +1,263 @@
+package todoist
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// maxCompletedTasksLimit is the largest page of completed tasks the server returns
+const maxCompletedTasksLimit = 200
+
+// syncTimeFormat is how the Sync API takes times in parameters, always in UTC
+const syncTimeFormat = "2006-01-02T15:04"
+
+// ErrIteratorDone is returned by an iterator's Next when there are no more items
+var ErrIteratorDone = errors.New("todoist: no more items in iterator")
+
+// CompletedTask is a task from the completed history, along with when it was completed. Only the fields the history keeps are filled in.
+type CompletedTask struct {
+	Task
+	CompletionID int64     `json:"completion_id"` // the id of the completion, as a recurring task is completed many times
+	CompletedAt  time.Time `json:"completed_at"`
+}
+
+// CompletedTasksOptions narrow down the completed tasks to get. The zero value gets the 30 most recently completed tasks.
+type CompletedTasksOptions struct {
+	ProjectID int64
+	Since     time.Time // completed at or after
+	Until     time.Time // completed at or before
+	Limit     int       // up to 200; the server defaults to 30
+	Offset    int
+
+	// IncludeRelated fills in the page's Projects and Sections with the projects and sections the tasks are in
+	IncludeRelated bool
+}
+
+// Validate checks the options for values the server rejects. Nil options are valid.
+func (o *CompletedTasksOptions) Validate() error {
+	if o == nil {
+		return nil
+	}
+	if o.Limit < 0 || o.Limit > maxCompletedTasksLimit {
+		return fmt.Errorf("limit must be between 0 and %d", maxCompletedTasksLimit)
+	}
+	if o.Offset < 0 {
+		return errors.New("offset cannot be negative")
+	}
+	if !o.Since.IsZero() && !o.Until.IsZero() && o.Until.Before(o.Since) {
+		return errors.New("until cannot be before since")
+	}
+	return nil
+}
+
+func (o *CompletedTasksOptions) queryParams() map[string]string {
+	data := map[string]string{}
+	if o == nil {
+		return data
+	}
+	if o.ProjectID != 0 {
+		data["project_id"] = fmt.Sprintf("%d", o.ProjectID)
+	}
+	if !o.Since.IsZero() {
+		data["since"] = o.Since.UTC().Format(syncTimeFormat)
+	}
+	if !o.Until.IsZero() {
+		data["until"] = o.Until.UTC().Format(syncTimeFormat)
+	}
+	if o.Limit != 0 {
+		data["limit"] = strconv.Itoa(o.Limit)
+	}
+	if o.Offset != 0 {
+		data["offset"] = strconv.Itoa(o.Offset)
+	}
+	return data
+}
+
+// CompletedTasksPage is a page of completed tasks, along with the projects and sections they are in if the options asked for them.
+// Otherwise Projects and Sections are nil.
+type CompletedTasksPage struct {
+	Tasks    []CompletedTask
+	Projects map[int64]Project
+	Sections map[int64]Section
+}
+
+// completedItem is an entry of the completed history as the Sync API represents it
+type completedItem struct {
+	ID            int64  `json:"id"`
+	TaskID        int64  `json:"task_id"`
+	UserID        int64  `json:"user_id"`
+	ProjectID     int64  `json:"project_id"`
+	SectionID     int64  `json:"section_id"`
+	Content       string `json:"content"`
+	NoteCount     int64  `json:"note_count"`
+	CompletedDate string `json:"completed_date"`
+}
+
+// completedResponse is the shape of a /completed/get_all response
+type completedResponse struct {
+	Items    []completedItem        `json:"items"`
+	Projects map[string]syncProject `json:"projects"`
+	Sections map[string]syncSection `json:"sections"`
+}
+
+func (r *completedResponse) toPage(includeRelated bool) (*CompletedTasksPage, error) {
+	page := &CompletedTasksPage{
+		Tasks: make([]CompletedTask, 0, len(r.Items)),
+	}
+	for _, item := range r.Items {
+		completedAt, err := time.Parse(time.RFC3339, item.CompletedDate)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse the completion date of task %d: %w", item.TaskID, err)
+		}
+		page.Tasks = append(page.Tasks, CompletedTask{
+			Task: Task{
+				ID:           item.TaskID,
+				ProjectID:    item.ProjectID,
+				SectionID:    item.SectionID,
+				Content:      item.Content,
+				Completed:    true,
+				LabelIDs:     []int64{},
+				URL:          fmt.Sprintf("https://todoist.com/showTask?id=%d", item.TaskID),
+				CommentCount: item.NoteCount,
+			},
+			CompletionID: item.ID,
+			CompletedAt:  completedAt,
+		})
+	}
+	if !includeRelated {
+		return page, nil
+	}
+	page.Projects = make(map[int64]Project, len(r.Projects))
+	for _, project := range r.Projects {
+		page.Projects[project.ID] = project.toProject()
+	}
+	page.Sections = make(map[int64]Section, len(r.Sections))
+	for _, section := range r.Sections {
+		page.Sections[section.ID] = section.toSection()
+	}
+	return page, nil
+}
+
+// GetCompletedTasks gets a page of the tasks completed by the user, most recent first. The options may be nil. This requires a premium
+// account. https://developer.todoist.com/sync/v8/#get-all-completed-items
+func GetCompletedTasks(token string, opts *CompletedTasksOptions) (*CompletedTasksPage, error) {
+	return clientForToken(token).GetCompletedTasks(opts)
+}
+
+// GetCompletedTasksWithContext is GetCompletedTasks with a context that can cancel the call or set its deadline
+func GetCompletedTasksWithContext(ctx context.Context, token string, opts *CompletedTasksOptions) (*CompletedTasksPage, error) {
+	return clientForToken(token).GetCompletedTasksWithContext(ctx, opts)
+}
+
+// CompletedTasks returns an iterator over the completed tasks that match the options, starting at their offset. The limit sets the page
+// size, which defaults to the largest the server allows. The options may be nil.
+func CompletedTasks(token string, opts *CompletedTasksOptions) *CompletedTaskIterator {
+	return clientForToken(token).CompletedTasks(opts)
+}
+
+// CompletedTasksWithContext is CompletedTasks with a context that can cancel the calls or set their deadline
+func CompletedTasksWithContext(ctx context.Context, token string, opts *CompletedTasksOptions) *CompletedTaskIterator {
+	return clientForToken(token).CompletedTasksWithContext(ctx, opts)
+}
+
+// GetCompletedTasks gets a page of the tasks completed by the user, most recent first. The options may be nil. This requires a premium
+// account. https://developer.todoist.com/sync/v8/#get-all-completed-items
+func (c *Client) GetCompletedTasks(opts *CompletedTasksOptions) (*CompletedTasksPage, error) {
+	return c.GetCompletedTasksWithContext(context.Background(), opts)
+}
+
+// GetCompletedTasksWithContext is GetCompletedTasks with a context that can cancel the call or set its deadline
+func (c *Client) GetCompletedTasksWithContext(ctx context.Context, opts *CompletedTasksOptions) (*CompletedTasksPage, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	resp, err := c.makeCall(ctx, EndpointNameGetCompletedTasks, map[string]string{}, opts.queryParams())
+	if err != nil {
+		return nil, err
+	}
+	decoded := &completedResponse{}
+	if err = json.Unmarshal(resp.Body, decoded); err != nil {
+		return nil, err
+	}
+	return decoded.toPage(opts != nil && opts.IncludeRelated)
+}
+
+// CompletedTaskIterator walks through the completed tasks, fetching the next page when the current one runs out
+type CompletedTaskIterator struct {
+	client *Client
+	ctx    context.Context
+	opts   CompletedTasksOptions
+
+	page     []CompletedTask
+	done     bool
+	projects map[int64]Project
+	sections map[int64]Section
+}
+
+// CompletedTasks returns an iterator over the completed tasks that match the options, starting at their offset. The limit sets the page
+// size, which defaults to the largest the server allows. The options may be nil.
+func (c *Client) CompletedTasks(opts *CompletedTasksOptions) *CompletedTaskIterator {
+	return c.CompletedTasksWithContext(context.Background(), opts)
+}
+
+// CompletedTasksWithContext is CompletedTasks with a context that can cancel the calls or set their deadline
+func (c *Client) CompletedTasksWithContext(ctx context.Context, opts *CompletedTasksOptions) *CompletedTaskIterator {
+	it := &CompletedTaskIterator{
+		client:   c,
+		ctx:      ctx,
+		projects: map[int64]Project{},
+		sections: map[int64]Section{},
+	}
+	if opts != nil {
+		it.opts = *opts
+	}
+	if it.opts.Limit == 0 {
+		it.opts.Limit = maxCompletedTasksLimit
+	}
+	return it
+}
+
+// Next returns the next completed task, or ErrIteratorDone when there are no more
+func (it *CompletedTaskIterator) Next() (*CompletedTask, error) {
+	if len(it.page) == 0 {
+		if it.done {
+			return nil, ErrIteratorDone
+		}
+		page, err := it.client.GetCompletedTasksWithContext(it.ctx, &it.opts)
+		if err != nil {
+			return nil, err
+		}
+		for id, project := range page.Projects {
+			it.projects[id] = project
+		}
+		for id, section := range page.Sections {
+			it.sections[id] = section
+		}
+		it.page = page.Tasks
+		it.opts.Offset += len(page.Tasks)
+		// a short page is the last one
+		it.done = len(page.Tasks) < it.opts.Limit
+		if len(it.page) == 0 {
+			return nil, ErrIteratorDone
+		}
+	}
+	task := it.page[0]
+	it.page = it.page[1:]
+	return &task, nil
+}
+
+// Project returns a project that a task returned so far is in. Projects are only known if the options set IncludeRelated.
+func (it *CompletedTaskIterator) Project(id int64) (Project, bool) {
+	project, found := it.projects[id]
+	return project, found
+}
+
+// Section returns a section that a task returned so far is in. Sections are only known if the options set IncludeRelated.
+func (it *CompletedTaskIterator) Section(id int64) (Section, bool) {
+	section, found := it.sections[id]
+	return section, found
+}
@@ -0,0 +1,116 @@
+package todoist
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newCompletedTestClient seeds a fake Todoist with five completed tasks in project 2, most recent first
+func newCompletedTestClient(t *testing.T) (*fakeTodoist, *Client) {
+	fake, client := newFakeTodoistClient(t)
+	fake.seed("projects", map[string]interface{}{"id": 2, "name": "Work"})
+	fake.seed("sections", map[string]interface{}{"id": 20, "name": "Doing", "project_id": 2})
+	for i := 0; i < 5; i++ {
+		fake.completed = append(fake.completed, map[string]interface{}{
+			"id":             100 + i,
+			"task_id":        10 + i,
+			"project_id":     2,
+			"section_id":     20,
+			"content":        fmt.Sprintf("Task %d", i),
+			"note_count":     1,
+			"completed_date": fmt.Sprintf("2021-06-%02dT10:00:00Z", 10-i),
+		})
+	}
+	return fake, client
+}
+
+func TestGetCompletedTasks(t *testing.T) {
+	fake, client := newCompletedTestClient(t)
+
+	_, err := client.GetCompletedTasks(&CompletedTasksOptions{Limit: 201})
+	assert.NotNil(t, err)
+	_, err = client.GetCompletedTasks(&CompletedTasksOptions{Offset: -1})
+	assert.NotNil(t, err)
+	_, err = client.GetCompletedTasks(&CompletedTasksOptions{Since: time.Now(), Until: time.Now().Add(-time.Hour)})
+	assert.NotNil(t, err)
+	assert.Empty(t, fake.calls("completed/get_all"))
+
+	since := time.Date(2021, 6, 1, 2, 30, 0, 0, time.FixedZone("UTC+2", 2*60*60))
+	until := time.Date(2021, 6, 30, 0, 0, 0, 0, time.UTC)
+	page, err := client.GetCompletedTasks(&CompletedTasksOptions{ProjectID: 2, Since: since, Until: until, Limit: 3, Offset: 1, IncludeRelated: true})
+	require.Nil(t, err)
+	queries := fake.calls("completed/get_all")
+	require.Len(t, queries, 1)
+	assert.Equal(t, url.Values{
+		"project_id": {"2"},
+		"since":      {"2021-06-01T00:30"},
+		"until":      {"2021-06-30T00:00"},
+		"limit":      {"3"},
+		"offset":     {"1"},
+	}, queries[0])
+
+	require.Len(t, page.Tasks, 3)
+	first := page.Tasks[0]
+	assert.Equal(t, int64(11), first.ID)
+	assert.Equal(t, int64(101), first.CompletionID)
+	assert.Equal(t, "Task 1", first.Content)
+	assert.True(t, first.Completed)
+	assert.Equal(t, int64(1), first.CommentCount)
+	assert.Equal(t, time.Date(2021, 6, 9, 10, 0, 0, 0, time.UTC), first.CompletedAt)
+	assert.Equal(t, "Work", page.Projects[2].Name)
+	assert.Equal(t, "Doing", page.Sections[20].Name)
+
+	page, err = client.GetCompletedTasks(nil)
+	require.Nil(t, err)
+	assert.Len(t, page.Tasks, 5)
+	assert.Nil(t, page.Projects)
+	assert.Nil(t, page.Sections)
+}
+
+func TestCompletedTaskIterator(t *testing.T) {
+	fake, client := newCompletedTestClient(t)
+
+	it := client.CompletedTasksWithContext(context.Background(), &CompletedTasksOptions{Limit: 2, IncludeRelated: true})
+	ids := []int64{}
+	for {
+		task, err := it.Next()
+		if err == ErrIteratorDone {
+			break
+		}
+		require.Nil(t, err)
+		ids = append(ids, task.ID)
+	}
+	assert.Equal(t, []int64{10, 11, 12, 13, 14}, ids)
+	queries := fake.calls("completed/get_all")
+	require.Len(t, queries, 3)
+	assert.Equal(t, "", queries[0].Get("offset"))
+	assert.Equal(t, "2", queries[1].Get("offset"))
+	assert.Equal(t, "4", queries[2].Get("offset"))
+	_, err := it.Next()
+	assert.Equal(t, ErrIteratorDone, err)
+	assert.Len(t, fake.calls("completed/get_all"), 3)
+
+	project, found := it.Project(2)
+	assert.True(t, found)
+	assert.Equal(t, "Work", project.Name)
+	_, found = it.Section(20)
+	assert.True(t, found)
+
+	// a full last page takes one more request to find out it was the last
+	it = client.CompletedTasks(&CompletedTasksOptions{Limit: 5})
+	count := 0
+	for _, err = it.Next(); err == nil; _, err = it.Next() {
+		count++
+	}
+	assert.Equal(t, ErrIteratorDone, err)
+	assert.Equal(t, 5, count)
+	assert.Len(t, fake.calls("completed/get_all"), 5)
+	_, found = it.Project(2)
+	assert.False(t, found)
+}
@@ -2,18 +2,44 @@ package todoist
 
 import "os"
 
+// Configuration holds the settings read from the environment at startup. They are only used by the package-level functions; a Client
+// created with NewClient never reads them.
 type Configuration struct {
-	AuthToken string // should be set if, and only if, you are using this for a single user
+	AuthToken      string // should be set if, and only if, you are using this for a single user
+	BaseURL        string // the root of the API, such as a local stand-in or a proxy; defaults to https://api.todoist.com
+	APIVersion     string // the version of the REST API; defaults to v1
+	SyncAPIVersion string // the version of the Sync API; defaults to v8
 }
 
 var config *Configuration
 
+// defaultClient backs the package-level functions, such as GetAllProjects, that take a token instead of being called on a Client
+var defaultClient *Client
+
 func setup() {
 	if config != nil {
 		return
 	}
 	config = &Configuration{}
 	config.AuthToken = envHelper("TODOIST_AUTH_TOKEN", "")
+	config.BaseURL = envHelper("TODOIST_BASE_URL", defaultBaseURL)
+	config.APIVersion = envHelper("TODOIST_API_VERSION", defaultAPIVersion)
+	config.SyncAPIVersion = envHelper("TODOIST_SYNC_API_VERSION", defaultSyncAPIVersion)
+	defaultClient = newDefaultClient()
+}
+
+// newDefaultClient creates the client for the package-level functions from the configuration
+func newDefaultClient() *Client {
+	return NewClient(config.AuthToken, WithBaseURL(config.BaseURL), WithAPIVersion(config.APIVersion), WithSyncAPIVersion(config.SyncAPIVersion))
+}
+
+// clientForToken returns the client the package-level functions use for a token. If the token is blank, the token from the environment
+// is used instead.
+func clientForToken(token string) *Client {
+	if token == "" {
+		token = config.AuthToken
+	}
+	return defaultClient.withToken(token)
 }
 
 func envHelper(key, defaultValue string) string {
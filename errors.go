@@ -0,0 +1,70 @@
+package todoist
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// The sentinel errors classify an APIError, so callers can branch with errors.Is instead of matching on the message, such as
+// errors.Is(err, todoist.ErrNotFound)
+var (
+	// ErrBadRequest means Todoist rejected the request as invalid (400)
+	ErrBadRequest = errors.New("todoist: bad request")
+	// ErrUnauthorized means the token was missing or invalid (401)
+	ErrUnauthorized = errors.New("todoist: unauthorized")
+	// ErrForbidden means the token may not access the resource (403)
+	ErrForbidden = errors.New("todoist: forbidden")
+	// ErrNotFound means the resource does not exist or is not visible to the user (404)
+	ErrNotFound = errors.New("todoist: not found")
+	// ErrRateLimited means the user has exceeded the request quota (429)
+	ErrRateLimited = errors.New("todoist: rate limited")
+	// ErrServerError means Todoist failed to handle the request (5xx)
+	ErrServerError = errors.New("todoist: server error")
+)
+
+// APIError is returned for every call that receives a non-2xx response from Todoist
+type APIError struct {
+	StatusCode int           // the HTTP status code of the response
+	Endpoint   string        // the name of the endpoint, such as EndpointNameGetTask
+	Method     string        // the HTTP method of the request
+	Body       string        // the raw body of the response
+	RequestID  string        // the X-Request-Id of the call, if any
+	RetryAfter time.Duration // how long Todoist asked us to wait before trying again, if it said so
+}
+
+// Error returns the message Todoist sent, or a description of the status if there was none
+func (e *APIError) Error() string {
+	body := strings.TrimRight(e.Body, "\n")
+	if body != "" {
+		return body
+	}
+	return fmt.Sprintf("%s %s received status code %d", e.Method, e.Endpoint, e.StatusCode)
+}
+
+// Is reports whether the error belongs to the target classification
+func (e *APIError) Is(target error) bool {
+	kind := e.Kind()
+	return kind != nil && kind == target
+}
+
+// Kind classifies the error by its status code. It returns nil for statuses that do not match one of the sentinel errors.
+func (e *APIError) Kind() error {
+	switch {
+	case e.StatusCode == http.StatusBadRequest:
+		return ErrBadRequest
+	case e.StatusCode == http.StatusUnauthorized:
+		return ErrUnauthorized
+	case e.StatusCode == http.StatusForbidden:
+		return ErrForbidden
+	case e.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case e.StatusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case e.StatusCode >= http.StatusInternalServerError:
+		return ErrServerError
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package todoist
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIErrorClassification(t *testing.T) {
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "request-42")
+		w.WriteHeader(status)
+		if status != http.StatusBadGateway {
+			w.Write([]byte("Something went wrong\n"))
+		}
+	}))
+	defer server.Close()
+	client := NewClient("test", WithBaseURL(server.URL), WithRetryPolicy(RetryPolicy{}))
+
+	cases := map[int]error{
+		http.StatusBadRequest:          ErrBadRequest,
+		http.StatusUnauthorized:        ErrUnauthorized,
+		http.StatusForbidden:           ErrForbidden,
+		http.StatusNotFound:            ErrNotFound,
+		http.StatusTooManyRequests:     ErrRateLimited,
+		http.StatusInternalServerError: ErrServerError,
+		http.StatusServiceUnavailable:  ErrServerError,
+	}
+	for code, expected := range cases {
+		status = code
+		task, err := client.GetActiveTask(42)
+		assert.Nil(t, task)
+		require.NotNil(t, err)
+		assert.True(t, errors.Is(err, expected), "status %d", code)
+		assert.False(t, errors.Is(err, ErrRateLimited) && expected != ErrRateLimited)
+		apiErr := &APIError{}
+		require.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, code, apiErr.StatusCode)
+		assert.Equal(t, EndpointNameGetTask, apiErr.Endpoint)
+		assert.Equal(t, http.MethodGet, apiErr.Method)
+		assert.Equal(t, "request-42", apiErr.RequestID)
+		assert.Equal(t, "Something went wrong", err.Error())
+	}
+
+	// without a body, the message describes the call instead
+	status = http.StatusBadGateway
+	err := client.DeleteTask(42)
+	require.NotNil(t, err)
+	assert.True(t, errors.Is(err, ErrServerError))
+	assert.Equal(t, "DELETE DeleteTask received status code 502", err.Error())
+
+	// an unclassified status is still an APIError, but matches none of the sentinels
+	status = http.StatusConflict
+	err = client.CloseTask(42)
+	require.NotNil(t, err)
+	apiErr := &APIError{}
+	require.True(t, errors.As(err, &apiErr))
+	assert.Nil(t, apiErr.Kind())
+	assert.False(t, errors.Is(err, ErrBadRequest))
+}
@@ -0,0 +1,654 @@
+package todoist
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeToken is the only token the fake Todoist accepts
+const fakeToken = "fake-todoist-token"
+
+// TestMain runs the tests against a fake, in-memory Todoist unless a real token is provided through the environment, so the suite works
+// offline
+func TestMain(m *testing.M) {
+	setup()
+	if config.AuthToken == "" {
+		server := httptest.NewServer(newFakeTodoist())
+		config.AuthToken = fakeToken
+		config.BaseURL = server.URL
+		defaultClient = newDefaultClient()
+		code := m.Run()
+		server.Close()
+		os.Exit(code)
+	}
+	os.Exit(m.Run())
+}
+
+// newFakeTodoistClient starts a fake Todoist of the test's own, so the test can seed it and look at what was sent without affecting the
+// others
+func newFakeTodoistClient(t *testing.T) (*fakeTodoist, *Client) {
+	fake := newFakeTodoist()
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	return fake, NewClient(fakeToken, WithBaseURL(server.URL))
+}
+
+// fakeTodoist is a small in-memory stand-in for the REST and Sync APIs. It only models the behavior the tests rely on.
+type fakeTodoist struct {
+	lock        sync.Mutex
+	nextID      int64
+	collections map[string]map[int64]map[string]interface{}
+	closed      map[int64]bool
+	tempIDs     map[string]int64
+
+	// completed is the completed task history and events the activity log, both newest first, and stats the productivity stats. The
+	// tests seed them directly.
+	completed []map[string]interface{}
+	events    []map[string]interface{}
+	stats     interface{}
+	// templates are the CSV template of each project, by project id
+	templates map[int64][]byte
+
+	// listQueries are the query of each REST list, by collection
+	listQueries map[string][]url.Values
+	// syncRequests are the commands of each Sync API write, syncReads the resource types of each Sync API read, and syncCalls the
+	// params of the calls to every other Sync API endpoint, by endpoint
+	syncRequests [][]map[string]interface{}
+	syncReads    [][]string
+	syncCalls    map[string][]url.Values
+	// commandErrors fail every command of a type, such as section_reorder, with the error
+	commandErrors map[string]*CommandError
+}
+
+// fakeRequiredFields are the fields that must be present, and not blank, to create an entity
+var fakeRequiredFields = map[string][]string{
+	"projects": {"name"},
+	"tasks":    {"content"},
+	"sections": {"name", "project_id"},
+	"labels":   {"name"},
+	"comments": {"content"},
+}
+
+// fakeSyncOnly are the collections only the Sync API serves
+var fakeSyncOnly = map[string]bool{
+	"reminders": true,
+	"filters":   true,
+}
+
+// fakeSyncObjects maps the objects named by the Sync API, such as the item of item_move or the project of project_id, to their collection
+var fakeSyncObjects = map[string]string{
+	"project":  "projects",
+	"item":     "tasks",
+	"section":  "sections",
+	"label":    "labels",
+	"note":     "comments",
+	"reminder": "reminders",
+	"filter":   "filters",
+}
+
+// fakeSyncResources maps the resource types of a Sync API read to their collection
+var fakeSyncResources = map[SyncResourceType]string{
+	SyncResourceProjects:  "projects",
+	SyncResourceItems:     "tasks",
+	SyncResourceSections:  "sections",
+	SyncResourceLabels:    "labels",
+	SyncResourceNotes:     "comments",
+	SyncResourceReminders: "reminders",
+	SyncResourceFilters:   "filters",
+}
+
+// fakeCollaborators are the collaborators of every project
+var fakeCollaborators = []Collaborator{
+	{ID: 1, Name: "Ada Lovelace", Email: "ada@example.com"},
+	{ID: 2, Name: "Grace Hopper", Email: "grace@example.com"},
+}
+
+func newFakeTodoist() *fakeTodoist {
+	return &fakeTodoist{
+		nextID: 1000,
+		collections: map[string]map[int64]map[string]interface{}{
+			"projects":  {},
+			"tasks":     {},
+			"sections":  {},
+			"labels":    {},
+			"comments":  {},
+			"reminders": {},
+			"filters":   {},
+		},
+		closed:        map[int64]bool{},
+		tempIDs:       map[string]int64{},
+		listQueries:   map[string][]url.Values{},
+		syncCalls:     map[string][]url.Values{},
+		templates:     map[int64][]byte{},
+		commandErrors: map[string]*CommandError{},
+	}
+}
+
+// seed adds the entities to the collection as they are, without the checks of creating them
+func (f *fakeTodoist) seed(name string, entities ...map[string]interface{}) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for _, entity := range entities {
+		f.collections[name][f.id(entity["id"])] = entity
+	}
+}
+
+// commands returns the commands of every Sync API write, in the order they were sent
+func (f *fakeTodoist) commands() []map[string]interface{} {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	commands := []map[string]interface{}{}
+	for _, request := range f.syncRequests {
+		commands = append(commands, request...)
+	}
+	return commands
+}
+
+// calls returns the params of each call to a Sync API endpoint, such as quick/add, in the order they were sent
+func (f *fakeTodoist) calls(endpoint string) []url.Values {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return append([]url.Values{}, f.syncCalls[endpoint]...)
+}
+
+// commandTypes returns the type of every command sent, in order
+func (f *fakeTodoist) commandTypes() []string {
+	types := []string{}
+	for _, cmd := range f.commands() {
+		types = append(types, cmd["type"].(string))
+	}
+	return types
+}
+
+func (f *fakeTodoist) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	auth := r.Header.Get("Authorization")
+	if auth == "" {
+		http.Error(w, "Empty token", http.StatusUnauthorized)
+		return
+	}
+	if auth != "Bearer "+fakeToken {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	// Sync API paths look like /sync/v8/{endpoint}
+	if endpoint := strings.TrimPrefix(r.URL.Path, "/sync/"+defaultSyncAPIVersion+"/"); endpoint != r.URL.Path {
+		f.serveSync(w, r, endpoint)
+		return
+	}
+
+	// paths look like /rest/v1/{collection}[/{id}[/{action}]]
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/rest/"+defaultAPIVersion), "/"), "/")
+	collection, found := f.collections[parts[0]]
+	if !found || fakeSyncOnly[parts[0]] {
+		http.NotFound(w, r)
+		return
+	}
+
+	if len(parts) == 1 {
+		switch r.Method {
+		case http.MethodGet:
+			f.list(w, r, parts[0], collection)
+		case http.MethodPost:
+			f.create(w, r, parts[0], collection)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+		return
+	}
+
+	id, _ := strconv.ParseInt(parts[1], 10, 64)
+	entity, found := collection[id]
+	if !found || (parts[0] == "tasks" && f.closed[id] && len(parts) == 2) {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	if len(parts) == 3 {
+		switch parts[2] {
+		case "collaborators":
+			writeFakeJSON(w, fakeCollaborators)
+			return
+		case "close":
+			f.closed[id] = true
+		case "reopen":
+			delete(f.closed, id)
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeFakeJSON(w, entity)
+	case http.MethodPost:
+		input, err := readFakeInput(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if parts[0] == "sections" {
+			// sections cannot move between projects
+			delete(input, "project_id")
+		}
+		f.apply(parts[0], entity, input)
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		delete(collection, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (f *fakeTodoist) list(w http.ResponseWriter, r *http.Request, name string, collection map[int64]map[string]interface{}) {
+	f.listQueries[name] = append(f.listQueries[name], r.URL.Query())
+	found := []map[string]interface{}{}
+	for id, entity := range collection {
+		if name == "tasks" && f.closed[id] {
+			continue
+		}
+		// every query param, such as project_id, filters on the field of the same name, except for the ones that match within a list and
+		// the Todoist filter, which is not evaluated
+		matches := true
+		for k := range r.URL.Query() {
+			switch k {
+			case "filter", "lang":
+			case "label_id":
+				matches = matches && strings.Contains(" "+strings.Trim(fmt.Sprintf("%v", entity["label_ids"]), "[]")+" ", " "+r.URL.Query().Get(k)+" ")
+			case "ids":
+				matches = matches && strings.Contains(","+r.URL.Query().Get(k)+",", fmt.Sprintf(",%v,", id))
+			default:
+				if fmt.Sprintf("%v", entity[k]) != r.URL.Query().Get(k) {
+					matches = false
+				}
+			}
+		}
+		if matches {
+			found = append(found, entity)
+		}
+	}
+	writeFakeJSON(w, found)
+}
+
+func (f *fakeTodoist) create(w http.ResponseWriter, r *http.Request, name string, collection map[int64]map[string]interface{}) {
+	input, err := readFakeInput(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	for _, required := range fakeRequiredFields[name] {
+		if input[required] == nil || input[required] == "" {
+			http.Error(w, fmt.Sprintf("%s is required", required), http.StatusBadRequest)
+			return
+		}
+	}
+	f.nextID++
+	entity := map[string]interface{}{
+		"id": f.nextID,
+	}
+	switch name {
+	case "tasks":
+		entity["priority"] = PriorityNormal
+		entity["label_ids"] = []int64{}
+	case "comments":
+		entity["posted"] = "2021-06-01T12:00:00Z"
+	}
+	f.apply(name, entity, input)
+	collection[f.nextID] = entity
+	writeFakeJSON(w, entity)
+}
+
+// apply copies the input onto the entity, lifting the due fields of a task into its due object
+func (f *fakeTodoist) apply(name string, entity map[string]interface{}, input map[string]interface{}) {
+	for k, v := range input {
+		if v == nil {
+			continue
+		}
+		if name == "tasks" && strings.HasPrefix(k, "due_") {
+			due, _ := entity["due"].(map[string]interface{})
+			if due == nil {
+				due = map[string]interface{}{}
+			}
+			due[strings.TrimPrefix(k, "due_")] = v
+			entity["due"] = due
+			continue
+		}
+		entity[k] = v
+	}
+}
+
+// serveSync answers the Sync API endpoints the client calls, recording the params of each call other than a sync
+func (f *fakeTodoist) serveSync(w http.ResponseWriter, r *http.Request, endpoint string) {
+	if err := r.ParseMultipartForm(1 << 20); err != nil && err != http.ErrNotMultipart {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if endpoint == "sync" {
+		f.sync(w, r)
+		return
+	}
+	params := r.Form
+	f.syncCalls[endpoint] = append(f.syncCalls[endpoint], params)
+	switch endpoint {
+	case "projects/get_archived":
+		writeFakeJSON(w, fakePage(f.archived("projects", ""), params))
+	case "sections/get_archived":
+		writeFakeJSON(w, f.archived("sections", params.Get("project_id")))
+	case "completed/get_all":
+		f.completedTasks(w, params)
+	case "activity/get":
+		// the filters are only recorded, not applied
+		writeFakeJSON(w, map[string]interface{}{"events": fakePage(f.events, params), "count": len(f.events)})
+	case "completed/get_stats":
+		writeFakeJSON(w, f.stats)
+	case "quick/add":
+		f.quickAdd(w, params)
+	case "templates/export_as_file":
+		template, found := f.templates[f.id(params.Get("project_id"))]
+		if !found {
+			http.Error(w, "Project not found", http.StatusNotFound)
+			return
+		}
+		w.Write(template)
+	case "templates/import_into_project":
+		f.importTemplate(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// archived lists the archived entities of a collection, optionally only those of a project, in the order of their ids
+func (f *fakeTodoist) archived(name string, projectID string) []map[string]interface{} {
+	archived := []map[string]interface{}{}
+	for _, entity := range f.collections[name] {
+		if entity["is_archived"] == true && (projectID == "" || fmt.Sprintf("%v", entity["project_id"]) == projectID) {
+			archived = append(archived, entity)
+		}
+	}
+	sort.Slice(archived, func(i, j int) bool { return f.id(archived[i]["id"]) < f.id(archived[j]["id"]) })
+	return archived
+}
+
+// completedTasks serves a page of the completed task history, optionally only that of a project, with the projects and sections the
+// page refers to
+func (f *fakeTodoist) completedTasks(w http.ResponseWriter, params url.Values) {
+	items := []map[string]interface{}{}
+	for _, item := range f.completed {
+		if params.Get("project_id") == "" || fmt.Sprintf("%v", item["project_id"]) == params.Get("project_id") {
+			items = append(items, item)
+		}
+	}
+	items = fakePage(items, params)
+	projects := map[string]interface{}{}
+	sections := map[string]interface{}{}
+	for _, item := range items {
+		if project, found := f.collections["projects"][f.id(item["project_id"])]; found {
+			projects[fmt.Sprintf("%v", item["project_id"])] = project
+		}
+		if section, found := f.collections["sections"][f.id(item["section_id"])]; found {
+			sections[fmt.Sprintf("%v", item["section_id"])] = section
+		}
+	}
+	writeFakeJSON(w, map[string]interface{}{"items": items, "projects": projects, "sections": sections})
+}
+
+// quickAdd adds a task from the text, understanding only #project, @label, and p1 to p4 among the words; the rest is the content
+func (f *fakeTodoist) quickAdd(w http.ResponseWriter, params url.Values) {
+	if strings.TrimSpace(params.Get("text")) == "" {
+		http.Error(w, "text is required", http.StatusBadRequest)
+		return
+	}
+	f.nextID++
+	task := map[string]interface{}{"id": f.nextID, "priority": PriorityNormal, "label_ids": []int64{}}
+	content := []string{}
+	for _, word := range strings.Fields(params.Get("text")) {
+		switch {
+		case strings.HasPrefix(word, "#") && f.named("projects", word[1:]) != 0:
+			task["project_id"] = f.named("projects", word[1:])
+		case strings.HasPrefix(word, "@") && f.named("labels", word[1:]) != 0:
+			task["label_ids"] = append(task["label_ids"].([]int64), f.named("labels", word[1:]))
+		case len(word) == 2 && word[0] == 'p' && word[1] >= '1' && word[1] <= '4':
+			task["priority"] = Priority(5 - int(word[1]-'0'))
+		default:
+			content = append(content, word)
+		}
+	}
+	task["content"] = strings.Join(content, " ")
+	f.collections["tasks"][f.nextID] = task
+	writeFakeJSON(w, fakeSyncItem(task))
+}
+
+// importTemplate replaces the template of an existing project with the uploaded CSV file
+func (f *fakeTodoist) importTemplate(w http.ResponseWriter, r *http.Request) {
+	projectID := f.id(r.FormValue("project_id"))
+	if _, found := f.collections["projects"][projectID]; !found {
+		http.Error(w, "Project not found", http.StatusNotFound)
+		return
+	}
+	file, header, err := r.FormFile("file")
+	if err != nil || !strings.HasSuffix(header.Filename, ".csv") {
+		http.Error(w, "a CSV file is required", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+	template, err := io.ReadAll(file)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	f.templates[projectID] = template
+	writeFakeJSON(w, map[string]interface{}{"status": "ok"})
+}
+
+// named finds the id of the entity of a collection with the name, or zero if there is none
+func (f *fakeTodoist) named(name string, entityName string) int64 {
+	for id, entity := range f.collections[name] {
+		if entity["name"] == entityName {
+			return id
+		}
+	}
+	return 0
+}
+
+// fakeSyncItem is a task as the Sync API represents it, with its labels under labels and its due time as the due date
+func fakeSyncItem(task map[string]interface{}) map[string]interface{} {
+	item := map[string]interface{}{}
+	for k, v := range task {
+		item[k] = v
+	}
+	item["labels"] = task["label_ids"]
+	delete(item, "label_ids")
+	if due, ok := task["due"].(map[string]interface{}); ok && due["datetime"] != nil {
+		item["due"] = map[string]interface{}{"date": due["datetime"], "string": due["string"]}
+	}
+	return item
+}
+
+// fakePage returns the part of the entities the limit and offset params ask for, 30 at a time by default
+func fakePage(entities []map[string]interface{}, params url.Values) []map[string]interface{} {
+	limit, offset := 30, 0
+	if value := params.Get("limit"); value != "" {
+		limit, _ = strconv.Atoi(value)
+	}
+	if value := params.Get("offset"); value != "" {
+		offset, _ = strconv.Atoi(value)
+	}
+	page := []map[string]interface{}{}
+	for i := offset; i < len(entities) && i < offset+limit; i++ {
+		page = append(page, entities[i])
+	}
+	return page
+}
+
+// sync reads the requested resource types, or runs the commands in order, failing the ones that name an object that does not exist
+func (f *fakeTodoist) sync(w http.ResponseWriter, r *http.Request) {
+	if r.PostForm.Get("commands") == "" {
+		types := []string{}
+		if err := json.Unmarshal([]byte(r.PostForm.Get("resource_types")), &types); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		f.syncReads = append(f.syncReads, types)
+		response := map[string]interface{}{"sync_token": "fake-sync-token", "full_sync": true}
+		for _, resource := range types {
+			entities := []map[string]interface{}{}
+			for _, entity := range f.collections[fakeSyncResources[SyncResourceType(resource)]] {
+				if resource == string(SyncResourceItems) {
+					entity = fakeSyncItem(entity)
+				}
+				entities = append(entities, entity)
+			}
+			sort.Slice(entities, func(i, j int) bool { return f.id(entities[i]["id"]) < f.id(entities[j]["id"]) })
+			response[resource] = entities
+		}
+		writeFakeJSON(w, response)
+		return
+	}
+
+	commands := []map[string]interface{}{}
+	if err := json.Unmarshal([]byte(r.PostForm.Get("commands")), &commands); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	f.syncRequests = append(f.syncRequests, commands)
+	statuses := map[string]interface{}{}
+	mapping := map[string]int64{}
+	for _, cmd := range commands {
+		uuid, _ := cmd["uuid"].(string)
+		if failure := f.runCommand(cmd, mapping); failure != nil {
+			statuses[uuid] = failure
+			continue
+		}
+		statuses[uuid] = "ok"
+	}
+	writeFakeJSON(w, map[string]interface{}{"sync_status": statuses, "temp_id_mapping": mapping})
+}
+
+// runCommand applies an add, update, move, archive, or delete to its collection, refusing an add without the required fields. Other
+// commands, such as the reorders, only check their ids.
+func (f *fakeTodoist) runCommand(cmd map[string]interface{}, mapping map[string]int64) *CommandError {
+	commandType, _ := cmd["type"].(string)
+	if failure := f.commandErrors[commandType]; failure != nil {
+		return failure
+	}
+	args, _ := cmd["args"].(map[string]interface{})
+	object := commandType[:strings.Index(commandType+"_", "_")]
+	action := strings.TrimPrefix(commandType, object+"_")
+	collection := f.collections[fakeSyncObjects[object]]
+	for k, v := range args {
+		referenced := strings.TrimSuffix(k, "_id")
+		if k == "parent_id" {
+			referenced = object
+		}
+		if k == "id" || k == referenced || v == nil || fakeSyncObjects[referenced] == "" {
+			continue
+		}
+		if _, found := f.collections[fakeSyncObjects[referenced]][f.id(v)]; !found {
+			return fakeNotFound(referenced)
+		}
+	}
+
+	if action == "add" {
+		for _, required := range fakeRequiredFields[fakeSyncObjects[object]] {
+			if args[required] == nil || args[required] == "" {
+				return &CommandError{Code: 19, Message: "Invalid argument value", HTTPCode: http.StatusBadRequest,
+					Extra: map[string]interface{}{"argument": required}}
+			}
+		}
+		f.nextID++
+		entity := map[string]interface{}{"id": f.nextID}
+		for k, v := range args {
+			entity[k] = f.resolve(v)
+		}
+		collection[f.nextID] = entity
+		if tempID, ok := cmd["temp_id"].(string); ok {
+			f.tempIDs[tempID] = f.nextID
+			mapping[tempID] = f.nextID
+		}
+		return nil
+	}
+	if args["id"] == nil {
+		return nil
+	}
+	id := f.id(args["id"])
+	entity, found := collection[id]
+	if !found {
+		return fakeNotFound(object)
+	}
+	switch action {
+	case "update", "move":
+		for k, v := range args {
+			if k != "id" {
+				entity[k] = f.resolve(v)
+			}
+		}
+	case "archive", "unarchive":
+		entity["is_archived"] = action == "archive"
+	case "delete":
+		delete(collection, id)
+	}
+	return nil
+}
+
+// resolve replaces the temp id of an object added earlier with its id, leaving any other value as it is
+func (f *fakeTodoist) resolve(value interface{}) interface{} {
+	if tempID, ok := value.(string); ok {
+		if id, found := f.tempIDs[tempID]; found {
+			return id
+		}
+	}
+	return value
+}
+
+// id reads an id as it was seeded, decoded from JSON, or sent as the temp id of an object added earlier
+func (f *fakeTodoist) id(value interface{}) int64 {
+	switch v := value.(type) {
+	case int:
+		return int64(v)
+	case int64:
+		return v
+	case float64:
+		return int64(v)
+	case string:
+		if id, found := f.tempIDs[v]; found {
+			return id
+		}
+		id, _ := strconv.ParseInt(v, 10, 64)
+		return id
+	}
+	return 0
+}
+
+func fakeNotFound(object string) *CommandError {
+	return &CommandError{Code: 20, Message: strings.ToUpper(object[:1]) + object[1:] + " not found", HTTPCode: http.StatusNotFound}
+}
+
+func readFakeInput(r *http.Request) (map[string]interface{}, error) {
+	input := map[string]interface{}{}
+	if r.ContentLength == 0 {
+		return input, nil
+	}
+	err := json.NewDecoder(r.Body).Decode(&input)
+	return input, err
+}
+
+func writeFakeJSON(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(data)
+}
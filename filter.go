@@ -0,0 +1,236 @@
+package todoist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Filter is a saved filter, which shows the tasks matching its query as a list of its own. https://developer.todoist.com/sync/v8/#filters
+type Filter struct {
+	ID       int64  `json:"id" db:"id"`
+	Name     string `json:"name" db:"name"`
+	Query    string `json:"query" db:"query"`
+	Color    Color  `json:"color" db:"color"`
+	Order    int64  `json:"order" db:"order"`
+	Favorite bool   `json:"favorite" db:"favorite"`
+}
+
+// FilterParams are the fields set during creating or updating a filter
+type FilterParams struct {
+	Name     *string `json:"name,omitempty" db:"name"`
+	Query    *string `json:"query,omitempty" db:"query"`
+	Color    Color   `json:"color,omitempty" db:"color"`
+	Order    *int64  `json:"order,omitempty" db:"order"`
+	Favorite *bool   `json:"favorite,omitempty" db:"favorite"`
+
+	// ValidateQuery checks the query with ValidateFilterQuery before it is sent. Leave it off for queries using syntax the SDK's parser
+	// does not know, which Todoist would accept.
+	ValidateQuery bool `json:"-" db:"-"`
+}
+
+// Validate checks the params, and the query if ValidateQuery is set
+func (p *FilterParams) Validate() error {
+	if p == nil {
+		return errors.New("you must pass in a valid input")
+	}
+	if p.Name != nil && *p.Name == "" {
+		return errors.New("name cannot be empty")
+	}
+	if p.Query != nil && *p.Query == "" {
+		return errors.New("query cannot be empty")
+	}
+	if p.ValidateQuery && p.Query != nil {
+		return ValidateFilterQuery(*p.Query)
+	}
+	return nil
+}
+
+// ValidateFilterQuery checks that a filter query parses, returning a *FilterParseError if not. Unlike ParseFilterQuery, it accepts commas,
+// which Todoist uses to show several lists in one filter, and checks each of the lists.
+func ValidateFilterQuery(query string) error {
+	start := 0
+	for i := 0; i <= len(query); i++ {
+		// a trailing backslash escapes nothing, so the last segment is left for the parser to reject
+		if i+1 < len(query) && query[i] == '\\' {
+			i++
+			continue
+		}
+		if i < len(query) && query[i] != ',' {
+			continue
+		}
+		if _, err := ParseFilterQuery(query[start:i]); err != nil {
+			parseErr := &FilterParseError{}
+			if errors.As(err, &parseErr) {
+				return &FilterParseError{Query: query, Offset: start + parseErr.Offset, Msg: parseErr.Msg}
+			}
+			return err
+		}
+		start = i + 1
+	}
+	return nil
+}
+
+// GetAllFilters returns all of the saved filters of the user. https://developer.todoist.com/sync/v8/#filters
+func GetAllFilters(token string) ([]Filter, error) {
+	return clientForToken(token).GetAllFilters()
+}
+
+// GetAllFiltersWithContext is GetAllFilters with a context that can cancel the call or set its deadline
+func GetAllFiltersWithContext(ctx context.Context, token string) ([]Filter, error) {
+	return clientForToken(token).GetAllFiltersWithContext(ctx)
+}
+
+// CreateFilter creates a saved filter, which requires a name and a query. https://developer.todoist.com/sync/v8/#add-a-filter
+func CreateFilter(token string, params *FilterParams) (*Filter, error) {
+	return clientForToken(token).CreateFilter(params)
+}
+
+// CreateFilterWithContext is CreateFilter with a context that can cancel the call or set its deadline
+func CreateFilterWithContext(ctx context.Context, token string, params *FilterParams) (*Filter, error) {
+	return clientForToken(token).CreateFilterWithContext(ctx, params)
+}
+
+// UpdateFilter updates a saved filter. https://developer.todoist.com/sync/v8/#update-a-filter
+func UpdateFilter(token string, filterID int64, params *FilterParams) (*Filter, error) {
+	return clientForToken(token).UpdateFilter(filterID, params)
+}
+
+// UpdateFilterWithContext is UpdateFilter with a context that can cancel the call or set its deadline
+func UpdateFilterWithContext(ctx context.Context, token string, filterID int64, params *FilterParams) (*Filter, error) {
+	return clientForToken(token).UpdateFilterWithContext(ctx, filterID, params)
+}
+
+// DeleteFilter deletes a saved filter. https://developer.todoist.com/sync/v8/#delete-a-filter
+func DeleteFilter(token string, filterID int64) error {
+	return clientForToken(token).DeleteFilter(filterID)
+}
+
+// DeleteFilterWithContext is DeleteFilter with a context that can cancel the call or set its deadline
+func DeleteFilterWithContext(ctx context.Context, token string, filterID int64) error {
+	return clientForToken(token).DeleteFilterWithContext(ctx, filterID)
+}
+
+// ReorderFilters puts the saved filters in the order of the ids. https://developer.todoist.com/sync/v8/#update-multiple-orders
+func ReorderFilters(token string, filterIDs []int64) error {
+	return clientForToken(token).ReorderFilters(filterIDs)
+}
+
+// ReorderFiltersWithContext is ReorderFilters with a context that can cancel the call or set its deadline
+func ReorderFiltersWithContext(ctx context.Context, token string, filterIDs []int64) error {
+	return clientForToken(token).ReorderFiltersWithContext(ctx, filterIDs)
+}
+
+// GetAllFilters returns all of the saved filters of the user, in their order. https://developer.todoist.com/sync/v8/#filters
+func (c *Client) GetAllFilters() ([]Filter, error) {
+	return c.GetAllFiltersWithContext(context.Background())
+}
+
+// GetAllFiltersWithContext is GetAllFilters with a context that can cancel the call or set its deadline
+func (c *Client) GetAllFiltersWithContext(ctx context.Context) ([]Filter, error) {
+	filters := []Filter{}
+	result, err := c.SyncWithContext(ctx, fullSyncToken, SyncResourceFilters)
+	if err != nil {
+		return filters, err
+	}
+	for i := range result.Filters {
+		if !result.Filters[i].IsDeleted {
+			filters = append(filters, result.Filters[i].Filter)
+		}
+	}
+	sort.SliceStable(filters, func(i, j int) bool { return filters[i].Order < filters[j].Order })
+	return filters, nil
+}
+
+// CreateFilter creates a saved filter, which requires a name and a query. https://developer.todoist.com/sync/v8/#add-a-filter
+func (c *Client) CreateFilter(params *FilterParams) (*Filter, error) {
+	return c.CreateFilterWithContext(context.Background(), params)
+}
+
+// CreateFilterWithContext is CreateFilter with a context that can cancel the call or set its deadline
+func (c *Client) CreateFilterWithContext(ctx context.Context, params *FilterParams) (*Filter, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	if params.Name == nil || params.Query == nil {
+		return nil, errors.New("name and query are required")
+	}
+	batch := NewCommandBatch()
+	cmd := batch.CreateFilter(params)
+	result, err := c.executeCommand(ctx, batch, cmd)
+	if err != nil {
+		return nil, err
+	}
+	filterID, found := result.ID(cmd.Ref())
+	if !found {
+		return nil, errors.New("the server did not return the id of the filter")
+	}
+	// the command only returns the id, so we need to
+	// get the filter if we want its information
+	return c.getFilter(ctx, filterID)
+}
+
+// UpdateFilter updates a saved filter. https://developer.todoist.com/sync/v8/#update-a-filter
+func (c *Client) UpdateFilter(filterID int64, params *FilterParams) (*Filter, error) {
+	return c.UpdateFilterWithContext(context.Background(), filterID, params)
+}
+
+// UpdateFilterWithContext is UpdateFilter with a context that can cancel the call or set its deadline
+func (c *Client) UpdateFilterWithContext(ctx context.Context, filterID int64, params *FilterParams) (*Filter, error) {
+	if filterID == 0 {
+		return nil, errors.New("filter id is required")
+	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	batch := NewCommandBatch()
+	if _, err := c.executeCommand(ctx, batch, batch.UpdateFilter(IDRef(filterID), params)); err != nil {
+		return nil, err
+	}
+	return c.getFilter(ctx, filterID)
+}
+
+// DeleteFilter deletes a saved filter. https://developer.todoist.com/sync/v8/#delete-a-filter
+func (c *Client) DeleteFilter(filterID int64) error {
+	return c.DeleteFilterWithContext(context.Background(), filterID)
+}
+
+// DeleteFilterWithContext is DeleteFilter with a context that can cancel the call or set its deadline
+func (c *Client) DeleteFilterWithContext(ctx context.Context, filterID int64) error {
+	if filterID == 0 {
+		return errors.New("filter id is required")
+	}
+	batch := NewCommandBatch()
+	_, err := c.executeCommand(ctx, batch, batch.DeleteFilter(IDRef(filterID)))
+	return err
+}
+
+// ReorderFilters puts the saved filters in the order of the ids. https://developer.todoist.com/sync/v8/#update-multiple-orders
+func (c *Client) ReorderFilters(filterIDs []int64) error {
+	return c.ReorderFiltersWithContext(context.Background(), filterIDs)
+}
+
+// ReorderFiltersWithContext is ReorderFilters with a context that can cancel the call or set its deadline
+func (c *Client) ReorderFiltersWithContext(ctx context.Context, filterIDs []int64) error {
+	if err := checkOrderIDs("filter", filterIDs); err != nil {
+		return err
+	}
+	batch := NewCommandBatch()
+	_, err := c.executeCommand(ctx, batch, batch.ReorderFilters(filterIDs))
+	return err
+}
+
+// getFilter finds a filter among the user's filters
+func (c *Client) getFilter(ctx context.Context, filterID int64) (*Filter, error) {
+	filters, err := c.GetAllFiltersWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range filters {
+		if filters[i].ID == filterID {
+			return &filters[i], nil
+		}
+	}
+	return nil, fmt.Errorf("filter %d: %w", filterID, ErrNotFound)
+}
@@ -0,0 +1,98 @@
+package todoist
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateFilterQuery(t *testing.T) {
+	for _, query := range []string{"today | overdue", "today, overdue", "(p1 | p2) & #Work, @waiting", `#Client\, Inc`} {
+		assert.Nil(t, ValidateFilterQuery(query), query)
+	}
+
+	err := ValidateFilterQuery("today, p1 & ")
+	parseErr := &FilterParseError{}
+	require.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, "today, p1 & ", parseErr.Query)
+	assert.Greater(t, parseErr.Offset, 6)
+	assert.NotNil(t, ValidateFilterQuery("today,"))
+
+	for _, query := range []string{`p1\`, `today, p1\`} {
+		err = ValidateFilterQuery(query)
+		require.ErrorAs(t, err, &parseErr, query)
+		assert.Equal(t, "the query ends with a backslash", parseErr.Msg)
+		assert.Equal(t, query, parseErr.Query)
+	}
+
+	params := &FilterParams{Name: String("Blocked"), Query: String("@blocked & (")}
+	assert.Nil(t, params.Validate())
+	params.ValidateQuery = true
+	assert.NotNil(t, params.Validate())
+	assert.NotNil(t, (&FilterParams{Name: String("")}).Validate())
+	var nilParams *FilterParams
+	assert.NotNil(t, nilParams.Validate())
+}
+
+func TestFilterCommands(t *testing.T) {
+	batch := NewCommandBatch()
+	created := batch.CreateFilter(&FilterParams{Name: String("Due this sprint"), Query: String("14 days"), Color: ColorBlue, Favorite: Bool(true)})
+	updated := batch.UpdateFilter(created.Ref(), &FilterParams{Order: Int64(2)})
+	reordered := batch.ReorderFilters([]int64{5, 3})
+
+	assert.Equal(t, "filter_add", created.Type)
+	assert.Equal(t, map[string]interface{}{"name": "Due this sprint", "query": "14 days", "color": ColorBlue, "is_favorite": true}, created.Args)
+	assert.Equal(t, map[string]interface{}{"id": created.Ref(), "item_order": int64(2)}, updated.Args)
+	assert.Equal(t, map[string]interface{}{"id_order_mapping": map[string]int64{"5": 1, "3": 2}}, reordered.Args)
+	assert.Equal(t, "filter_delete", batch.DeleteFilter(IDRef(5)).Type)
+}
+
+func TestFilterClient(t *testing.T) {
+	fake, client := newFakeTodoistClient(t)
+	fake.seed("filters",
+		map[string]interface{}{"id": 5, "name": "Blocked on review", "query": "@review", "color": 47, "item_order": 2, "is_favorite": 1},
+		map[string]interface{}{"id": 3, "name": "Due this sprint", "query": "14 days", "color": 30, "item_order": 1},
+		map[string]interface{}{"id": 4, "name": "Gone", "query": "p4", "item_order": 3, "is_deleted": 1},
+	)
+
+	found, err := client.GetAllFilters()
+	require.Nil(t, err)
+	assert.Equal(t, []Filter{
+		{ID: 3, Name: "Due this sprint", Query: "14 days", Color: ColorBerryRed, Order: 1},
+		{ID: 5, Name: "Blocked on review", Query: "@review", Color: Color(47), Order: 2, Favorite: true},
+	}, found)
+	assert.Equal(t, [][]string{{"filters"}}, fake.syncReads)
+
+	_, err = client.CreateFilter(&FilterParams{Name: String("Broken"), Query: String("p1 &"), ValidateQuery: true})
+	assert.NotNil(t, err)
+	_, err = client.CreateFilter(&FilterParams{Name: String("No query")})
+	assert.NotNil(t, err)
+	assert.Empty(t, fake.commands())
+
+	created, err := client.CreateFilter(&FilterParams{Name: String("Waiting"), Query: String("@waiting, @someday"), Order: Int64(4), ValidateQuery: true})
+	require.Nil(t, err)
+	assert.Equal(t, &Filter{ID: fake.nextID, Name: "Waiting", Query: "@waiting, @someday", Order: 4}, created)
+
+	updated, err := client.UpdateFilter(3, &FilterParams{Query: String("7 days")})
+	require.Nil(t, err)
+	assert.Equal(t, "7 days", updated.Query)
+	assert.Equal(t, "Due this sprint", updated.Name)
+
+	require.Nil(t, client.ReorderFilters([]int64{created.ID, 5, 3}))
+	assert.NotNil(t, client.ReorderFilters([]int64{6, 6}))
+
+	require.Nil(t, client.DeleteFilter(5))
+	err = client.DeleteFilter(5)
+	commandErr := &CommandError{}
+	require.ErrorAs(t, err, &commandErr)
+	assert.Equal(t, "Filter not found", commandErr.Message)
+	found, err = client.GetAllFilters()
+	require.Nil(t, err)
+	assert.Len(t, found, 2)
+
+	assert.Equal(t, []string{"filter_add", "filter_update", "filter_update_orders", "filter_delete", "filter_delete"}, fake.commandTypes())
+	assert.Equal(t, map[string]interface{}{fmt.Sprint(created.ID): float64(1), "5": float64(2), "3": float64(3)},
+		fake.commands()[2]["args"].(map[string]interface{})["id_order_mapping"])
+}
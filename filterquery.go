@@ -0,0 +1,631 @@
+package todoist
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FilterExpr is a node of a parsed filter query. https://todoist.com/help/articles/introduction-to-filters
+type FilterExpr interface {
+	// Match reports whether the task matches the expression, looking up names in the env
+	Match(task *Task, env *FilterEnv) bool
+	// String renders the expression back as a query
+	String() string
+}
+
+// FilterParseError is returned for a query that is not valid filter syntax
+type FilterParseError struct {
+	Query  string
+	Offset int // the byte offset in the query where the problem is
+	Msg    string
+}
+
+func (e *FilterParseError) Error() string {
+	return fmt.Sprintf("todoist: invalid filter query at column %d: %s", e.Offset+1, e.Msg)
+}
+
+// FilterAnd matches tasks that match both sides, written a & b
+type FilterAnd struct {
+	Left  FilterExpr
+	Right FilterExpr
+}
+
+// Match reports whether the task matches both sides
+func (f *FilterAnd) Match(task *Task, env *FilterEnv) bool {
+	return f.Left.Match(task, env) && f.Right.Match(task, env)
+}
+
+// String renders the expression as (a & b)
+func (f *FilterAnd) String() string {
+	return "(" + f.Left.String() + " & " + f.Right.String() + ")"
+}
+
+// FilterOr matches tasks that match either side, written a | b
+type FilterOr struct {
+	Left  FilterExpr
+	Right FilterExpr
+}
+
+// Match reports whether the task matches either side
+func (f *FilterOr) Match(task *Task, env *FilterEnv) bool {
+	return f.Left.Match(task, env) || f.Right.Match(task, env)
+}
+
+// String renders the expression as (a | b)
+func (f *FilterOr) String() string {
+	return "(" + f.Left.String() + " | " + f.Right.String() + ")"
+}
+
+// FilterNot matches tasks that do not match the expression, written !a
+type FilterNot struct {
+	Expr FilterExpr
+}
+
+// Match reports whether the task does not match the inner expression
+func (f *FilterNot) Match(task *Task, env *FilterEnv) bool {
+	return !f.Expr.Match(task, env)
+}
+
+// String renders the expression as !a
+func (f *FilterNot) String() string {
+	return "!" + f.Expr.String()
+}
+
+// FilterProject matches tasks in the projects with the name, written #Name, or also in their subprojects, written ##Name. The name
+// may contain * wildcards.
+type FilterProject struct {
+	Name         string
+	WithChildren bool
+}
+
+// Match reports whether the task is in one of the projects with the name, or in their subprojects if WithChildren is set
+func (f *FilterProject) Match(task *Task, env *FilterEnv) bool {
+	return env.projectIDs(f.Name, f.WithChildren)[task.ProjectID]
+}
+
+// String renders the expression as #Name, or ##Name with the subprojects
+func (f *FilterProject) String() string {
+	if f.WithChildren {
+		return "##" + escapeFilterName(f.Name)
+	}
+	return "#" + escapeFilterName(f.Name)
+}
+
+// FilterSection matches tasks in the sections with the name, written /Name. The name may contain * wildcards.
+type FilterSection struct {
+	Name string
+}
+
+// Match reports whether the task is in one of the sections with the name
+func (f *FilterSection) Match(task *Task, env *FilterEnv) bool {
+	return env.sectionIDs(f.Name)[task.SectionID]
+}
+
+// String renders the expression as /Name
+func (f *FilterSection) String() string {
+	return "/" + escapeFilterName(f.Name)
+}
+
+// FilterLabel matches tasks with the labels with the name, written @Name. The name may contain * wildcards. An empty name matches
+// tasks without labels, written "no labels".
+type FilterLabel struct {
+	Name string
+}
+
+// Match reports whether the task has one of the labels with the name, or no labels at all if the name is empty
+func (f *FilterLabel) Match(task *Task, env *FilterEnv) bool {
+	if f.Name == "" {
+		return len(task.LabelIDs) == 0
+	}
+	ids := env.labelIDs(f.Name)
+	for _, id := range task.LabelIDs {
+		if ids[id] {
+			return true
+		}
+	}
+	return false
+}
+
+// String renders the expression as @Name, or "no labels"
+func (f *FilterLabel) String() string {
+	if f.Name == "" {
+		return "no labels"
+	}
+	return "@" + escapeFilterName(f.Name)
+}
+
+// FilterPriority matches tasks with the priority, written p1 for the most urgent to p4 for normal. The Priority holds the API value,
+// which counts the other way.
+type FilterPriority struct {
+	Priority Priority
+}
+
+// Match reports whether the task has the priority
+func (f *FilterPriority) Match(task *Task, env *FilterEnv) bool {
+	return task.Priority == f.Priority
+}
+
+// String renders the expression as p1 to p4, in the app's numbering
+func (f *FilterPriority) String() string {
+	return fmt.Sprintf("p%d", 5-int(f.Priority))
+}
+
+// FilterDueKeyword names a kind of date condition
+type FilterDueKeyword string
+
+const (
+	FilterDueToday     FilterDueKeyword = "today"
+	FilterDueTomorrow  FilterDueKeyword = "tomorrow"
+	FilterDueOverdue   FilterDueKeyword = "overdue"
+	FilterDueNoDate    FilterDueKeyword = "no date"
+	FilterDueNextDays  FilterDueKeyword = "next days" // the next Days days, starting today
+	FilterDueRecurring FilterDueKeyword = "recurring"
+)
+
+// FilterDue matches tasks by their due date, relative to the env's current time
+type FilterDue struct {
+	Keyword FilterDueKeyword
+	Days    int // for FilterDueNextDays
+}
+
+// Match reports whether the task's due date meets the keyword, relative to the env's current time
+func (f *FilterDue) Match(task *Task, env *FilterEnv) bool {
+	if f.Keyword == FilterDueNoDate {
+		return task.Due.Date == ""
+	}
+	if task.Due.Date == "" {
+		return false
+	}
+	now := env.now()
+	today := now.Format("2006-01-02")
+	switch f.Keyword {
+	case FilterDueToday:
+		return task.Due.Date == today
+	case FilterDueTomorrow:
+		return task.Due.Date == now.AddDate(0, 0, 1).Format("2006-01-02")
+	case FilterDueOverdue:
+		if task.Due.Datetime != "" {
+			if due, err := parseDueDatetime(task.Due.Datetime, now.Location()); err == nil {
+				return due.Before(now)
+			}
+		}
+		return task.Due.Date < today
+	case FilterDueNextDays:
+		return task.Due.Date >= today && task.Due.Date < now.AddDate(0, 0, f.Days).Format("2006-01-02")
+	case FilterDueRecurring:
+		return task.Due.Recurring
+	}
+	return false
+}
+
+// String renders the expression as the keyword, or "next N days"
+func (f *FilterDue) String() string {
+	if f.Keyword == FilterDueNextDays {
+		return fmt.Sprintf("next %d days", f.Days)
+	}
+	return string(f.Keyword)
+}
+
+// parseDueDatetime parses the datetime of a due date, which is in UTC with a Z, or floating in the user's time zone without one
+func parseDueDatetime(value string, location *time.Location) (time.Time, error) {
+	if strings.HasSuffix(value, "Z") {
+		return time.Parse(time.RFC3339, value)
+	}
+	return time.ParseInLocation("2006-01-02T15:04:05", value, location)
+}
+
+// FilterAssigned matches tasks by their assignee. To is "me", "others", a collaborator's name or email, which may contain * wildcards,
+// or empty to match any assigned task.
+type FilterAssigned struct {
+	To string
+}
+
+// Match reports whether the task is assigned to whom To names, looking collaborators up in the env
+func (f *FilterAssigned) Match(task *Task, env *FilterEnv) bool {
+	switch strings.ToLower(f.To) {
+	case "":
+		return task.Assignee != 0
+	case "me":
+		return task.Assignee != 0 && task.Assignee == env.UserID
+	case "others":
+		return task.Assignee != 0 && task.Assignee != env.UserID
+	}
+	for _, collaborator := range env.Collaborators {
+		if collaborator.ID == task.Assignee && (matchFilterName(f.To, collaborator.Name) || matchFilterName(f.To, collaborator.Email)) {
+			return true
+		}
+	}
+	return false
+}
+
+// String renders the expression as "assigned", or "assigned to: " and the assignee
+func (f *FilterAssigned) String() string {
+	if f.To == "" {
+		return "assigned"
+	}
+	return "assigned to: " + escapeFilterName(f.To)
+}
+
+// FilterSearch matches tasks whose content contains the text, ignoring case, written search: text
+type FilterSearch struct {
+	Text string
+}
+
+// Match reports whether the task's content contains the text, ignoring case
+func (f *FilterSearch) Match(task *Task, env *FilterEnv) bool {
+	return strings.Contains(strings.ToLower(task.Content), strings.ToLower(f.Text))
+}
+
+// String renders the expression as search: text
+func (f *FilterSearch) String() string {
+	return "search: " + escapeFilterName(f.Text)
+}
+
+// FilterEnv holds the data a filter query refers to by name, along with the user and time it is evaluated for. Build it once for many
+// tasks; it caches the name lookups, so it must not be changed or copied after the first match.
+type FilterEnv struct {
+	Projects      []Project
+	Sections      []Section
+	Labels        []Label
+	Collaborators []Collaborator
+	UserID        int64          // the user "me" refers to
+	Now           time.Time      // defaults to the current time
+	Location      *time.Location // the time zone dates are compared in; defaults to that of Now
+
+	lock  sync.Mutex
+	cache map[string]map[int64]bool
+}
+
+func (env *FilterEnv) now() time.Time {
+	now := env.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	if env.Location != nil {
+		now = now.In(env.Location)
+	}
+	return now
+}
+
+// cached returns the ids for the key, computing them the first time
+func (env *FilterEnv) cached(key string, compute func() map[int64]bool) map[int64]bool {
+	env.lock.Lock()
+	defer env.lock.Unlock()
+	if env.cache == nil {
+		env.cache = map[string]map[int64]bool{}
+	}
+	ids, found := env.cache[key]
+	if !found {
+		ids = compute()
+		env.cache[key] = ids
+	}
+	return ids
+}
+
+func (env *FilterEnv) projectIDs(name string, withChildren bool) map[int64]bool {
+	return env.cached(fmt.Sprintf("project:%t:%s", withChildren, name), func() map[int64]bool {
+		ids := map[int64]bool{}
+		for _, project := range env.Projects {
+			if matchFilterName(name, project.Name) {
+				ids[project.ID] = true
+			}
+		}
+		if !withChildren {
+			return ids
+		}
+		// keep adding the children of found projects until there are no more
+		for added := true; added; {
+			added = false
+			for _, project := range env.Projects {
+				if !ids[project.ID] && ids[project.ParentID] {
+					ids[project.ID] = true
+					added = true
+				}
+			}
+		}
+		return ids
+	})
+}
+
+func (env *FilterEnv) sectionIDs(name string) map[int64]bool {
+	return env.cached("section:"+name, func() map[int64]bool {
+		ids := map[int64]bool{}
+		for _, section := range env.Sections {
+			if matchFilterName(name, section.Name) {
+				ids[section.ID] = true
+			}
+		}
+		return ids
+	})
+}
+
+func (env *FilterEnv) labelIDs(name string) map[int64]bool {
+	return env.cached("label:"+name, func() map[int64]bool {
+		ids := map[int64]bool{}
+		for _, label := range env.Labels {
+			if matchFilterName(name, label.Name) {
+				ids[label.ID] = true
+			}
+		}
+		return ids
+	})
+}
+
+// matchFilterName compares names ignoring case, with * in the pattern matching any run of characters
+func matchFilterName(pattern string, name string) bool {
+	pattern = strings.ToLower(pattern)
+	name = strings.ToLower(name)
+	if !strings.Contains(pattern, "*") {
+		return pattern == name
+	}
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(name, parts[0]) {
+		return false
+	}
+	name = name[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		index := strings.Index(name, part)
+		if index < 0 {
+			return false
+		}
+		name = name[index+len(part):]
+	}
+	return strings.HasSuffix(name, parts[len(parts)-1])
+}
+
+// filterSpecialChars are the characters a name must escape with a backslash
+const filterSpecialChars = `&|!(),\`
+
+func escapeFilterName(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if strings.ContainsRune(filterSpecialChars, r) {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// FilterTasks returns the tasks that match the expression
+func FilterTasks(tasks []Task, expr FilterExpr, env *FilterEnv) []Task {
+	matched := []Task{}
+	for i := range tasks {
+		if expr.Match(&tasks[i], env) {
+			matched = append(matched, tasks[i])
+		}
+	}
+	return matched
+}
+
+// ParseFilterQuery parses a filter query such as "(today | overdue) & p1 & #Work & @waiting". Operators are & (and), | (or), and
+// ! (not), and parentheses group. Characters that are operators must be escaped with a backslash in names. Queries with commas, which
+// Todoist shows as separate lists, must be split and parsed one at a time.
+func ParseFilterQuery(query string) (FilterExpr, error) {
+	tokens, err := lexFilterQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	p := &filterParser{query: query, tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != filterTokenEOF {
+		return nil, p.errorf(tok.offset, "unexpected %q", tok.text)
+	}
+	return expr, nil
+}
+
+type filterTokenKind int
+
+const (
+	filterTokenEOF filterTokenKind = iota
+	filterTokenAnd
+	filterTokenOr
+	filterTokenNot
+	filterTokenOpen
+	filterTokenClose
+	filterTokenTerm
+)
+
+type filterToken struct {
+	kind   filterTokenKind
+	text   string
+	offset int
+}
+
+func lexFilterQuery(query string) ([]filterToken, error) {
+	tokens := []filterToken{}
+	operators := map[byte]filterTokenKind{'&': filterTokenAnd, '|': filterTokenOr, '!': filterTokenNot, '(': filterTokenOpen, ')': filterTokenClose}
+	for i := 0; i < len(query); {
+		c := query[i]
+		if c == ' ' || c == '\t' || c == '\n' {
+			i++
+			continue
+		}
+		if c == ',' {
+			return nil, &FilterParseError{Query: query, Offset: i, Msg: "commas separate lists; parse each query on its own"}
+		}
+		if kind, ok := operators[c]; ok {
+			tokens = append(tokens, filterToken{kind: kind, text: string(c), offset: i})
+			i++
+			continue
+		}
+
+		// a term runs until the next operator that is not escaped
+		start := i
+		var text strings.Builder
+		for i < len(query) {
+			c = query[i]
+			if c == '\\' {
+				if i+1 == len(query) {
+					return nil, &FilterParseError{Query: query, Offset: i, Msg: "the query ends with a backslash"}
+				}
+				text.WriteByte(query[i+1])
+				i += 2
+				continue
+			}
+			if _, ok := operators[c]; ok || c == ',' {
+				break
+			}
+			text.WriteByte(c)
+			i++
+		}
+		tokens = append(tokens, filterToken{kind: filterTokenTerm, text: strings.TrimSpace(text.String()), offset: start})
+	}
+	return append(tokens, filterToken{kind: filterTokenEOF, offset: len(query)}), nil
+}
+
+type filterParser struct {
+	query  string
+	tokens []filterToken
+	pos    int
+}
+
+func (p *filterParser) peek() filterToken {
+	return p.tokens[p.pos]
+}
+
+func (p *filterParser) next() filterToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != filterTokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *filterParser) errorf(offset int, format string, args ...interface{}) error {
+	return &FilterParseError{Query: p.query, Offset: offset, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *filterParser) parseOr() (FilterExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == filterTokenOr {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &FilterOr{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (FilterExpr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == filterTokenAnd {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &FilterAnd{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseUnary() (FilterExpr, error) {
+	tok := p.next()
+	switch tok.kind {
+	case filterTokenNot:
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &FilterNot{Expr: expr}, nil
+	case filterTokenOpen:
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != filterTokenClose {
+			return nil, p.errorf(tok.offset, "the parenthesis is never closed")
+		}
+		return expr, nil
+	case filterTokenTerm:
+		return parseFilterTerm(tok.text, func(format string, args ...interface{}) error {
+			return p.errorf(tok.offset, format, args...)
+		})
+	case filterTokenEOF:
+		return nil, p.errorf(tok.offset, "the query ends where a condition is expected")
+	}
+	return nil, p.errorf(tok.offset, "unexpected %q where a condition is expected", tok.text)
+}
+
+var (
+	filterPriorityPattern = regexp.MustCompile(`^p([1-4])$`)
+	filterDaysPattern     = regexp.MustCompile(`^(?:next )?(\d+) days?$`)
+)
+
+// parseFilterTerm classifies a single condition
+func parseFilterTerm(text string, errorf func(format string, args ...interface{}) error) (FilterExpr, error) {
+	lower := strings.ToLower(text)
+	name := func(prefix string) (string, error) {
+		n := strings.TrimSpace(text[len(prefix):])
+		if n == "" {
+			return "", errorf("%q needs a name", prefix)
+		}
+		return n, nil
+	}
+
+	switch {
+	case strings.HasPrefix(text, "##"):
+		n, err := name("##")
+		return &FilterProject{Name: n, WithChildren: true}, err
+	case strings.HasPrefix(text, "#"):
+		n, err := name("#")
+		return &FilterProject{Name: n}, err
+	case strings.HasPrefix(text, "@"):
+		n, err := name("@")
+		return &FilterLabel{Name: n}, err
+	case strings.HasPrefix(text, "/"):
+		n, err := name("/")
+		return &FilterSection{Name: n}, err
+	case strings.HasPrefix(lower, "search:"):
+		n, err := name("search:")
+		return &FilterSearch{Text: n}, err
+	case strings.HasPrefix(lower, "assigned to:"):
+		n, err := name("assigned to:")
+		return &FilterAssigned{To: n}, err
+	case lower == "assigned":
+		return &FilterAssigned{}, nil
+	case lower == "no labels":
+		return &FilterLabel{}, nil
+	case lower == "today":
+		return &FilterDue{Keyword: FilterDueToday}, nil
+	case lower == "tomorrow":
+		return &FilterDue{Keyword: FilterDueTomorrow}, nil
+	case lower == "overdue" || lower == "od":
+		return &FilterDue{Keyword: FilterDueOverdue}, nil
+	case lower == "no date" || lower == "no due date":
+		return &FilterDue{Keyword: FilterDueNoDate}, nil
+	case lower == "recurring":
+		return &FilterDue{Keyword: FilterDueRecurring}, nil
+	}
+	if match := filterPriorityPattern.FindStringSubmatch(lower); match != nil {
+		level, _ := strconv.Atoi(match[1])
+		return &FilterPriority{Priority: Priority(5 - level)}, nil
+	}
+	if match := filterDaysPattern.FindStringSubmatch(lower); match != nil {
+		days, err := strconv.Atoi(match[1])
+		if err != nil || days < 1 {
+			return nil, errorf("%q needs a positive number of days", text)
+		}
+		return &FilterDue{Keyword: FilterDueNextDays, Days: days}, nil
+	}
+	if text == "" {
+		return nil, errorf("empty condition")
+	}
+	return nil, errorf("unknown condition %q", text)
+}
@@ -0,0 +1,134 @@
+package todoist
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newFilterTestEnv() *FilterEnv {
+	return &FilterEnv{
+		Projects: []Project{
+			{ID: 1, Name: "Work"},
+			{ID: 2, Name: "Clients", ParentID: 1},
+			{ID: 3, Name: "Acme", ParentID: 2},
+			{ID: 4, Name: "Home"},
+		},
+		Sections: []Section{
+			{ID: 10, ProjectID: 1, Name: "Doing"},
+		},
+		Labels: []Label{
+			{ID: 20, Name: "waiting"},
+			{ID: 21, Name: "waiting_on_bob"},
+		},
+		Collaborators: []Collaborator{
+			{ID: 100, Name: "Alex Doe", Email: "alex@example.com"},
+			{ID: 101, Name: "Sam Roe", Email: "sam@example.com"},
+		},
+		UserID: 100,
+		Now:    time.Date(2021, 6, 10, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestParseFilterQuery(t *testing.T) {
+	tests := map[string]string{
+		"(today | overdue) & p1 & #Work & @waiting": "((((today | overdue) & p1) & #Work) & @waiting)",
+		"!##Work | /Doing":                          "(!##Work | /Doing)",
+		"a | b & c":                                 "",
+		"next 7 days & no date":                     "(next 7 days & no date)",
+		"3 days":                                    "next 3 days",
+		"assigned to: me & !assigned":               "(assigned to: me & !assigned)",
+		"search: Q3 report & no labels":             "(search: Q3 report & no labels)",
+		`#Work \& Play`:                             `#Work \& Play`,
+		"P4 | recurring":                            "(p4 | recurring)",
+	}
+	for query, expected := range tests {
+		expr, err := ParseFilterQuery(query)
+		if expected == "" {
+			assert.NotNil(t, err, query)
+			continue
+		}
+		require.Nil(t, err, query)
+		assert.Equal(t, expected, expr.String(), query)
+	}
+}
+
+func TestParseFilterQueryErrors(t *testing.T) {
+	tests := map[string]int{
+		"":                    0,
+		"today &":             7,
+		"(today | overdue":    0,
+		"today)":              5,
+		"#Work & @":           8,
+		"today, overdue":      5,
+		"p1 & someday":        5,
+		"today & \\":          8,
+		"today & (p1 | )":     14,
+		"next 0 days | today": 0,
+	}
+	for query, offset := range tests {
+		_, err := ParseFilterQuery(query)
+		parseErr := &FilterParseError{}
+		require.True(t, errors.As(err, &parseErr), query)
+		assert.Equal(t, offset, parseErr.Offset, query)
+		assert.Equal(t, query, parseErr.Query)
+	}
+	_, err := ParseFilterQuery("p1 & someday")
+	assert.Equal(t, `todoist: invalid filter query at column 6: unknown condition "someday"`, err.Error())
+}
+
+func TestFilterMatch(t *testing.T) {
+	env := newFilterTestEnv()
+	tasks := []Task{
+		{ID: 1, ProjectID: 1, SectionID: 10, Content: "Write the Q3 report", Priority: PriorityUrgent, LabelIDs: []int64{20}, Due: TaskDueInfo{Date: "2021-06-10"}},
+		{ID: 2, ProjectID: 3, Content: "Call Acme", Priority: PriorityUrgent, LabelIDs: []int64{21}, Due: TaskDueInfo{Date: "2021-06-01"}, Assignee: 101},
+		{ID: 3, ProjectID: 4, Content: "Groceries", Priority: PriorityNormal, Due: TaskDueInfo{Date: "2021-06-15", Recurring: true}, Assignee: 100},
+		{ID: 4, ProjectID: 2, Content: "Invoice", Priority: PriorityHigh, Due: TaskDueInfo{Date: "2021-06-10", Datetime: "2021-06-10T09:00:00Z"}},
+		{ID: 5, ProjectID: 4, Content: "Someday", Priority: PriorityNormal},
+	}
+	tests := map[string][]int64{
+		"(today | overdue) & p1 & #Work & @waiting": {1},
+		"today":                   {1, 4},
+		"overdue":                 {2, 4},
+		"tomorrow":                {},
+		"next 7 days":             {1, 3, 4},
+		"no date":                 {5},
+		"#Work":                   {1},
+		"##Work":                  {1, 2, 4},
+		"##Clients & !#Acme":      {4},
+		"@waiting*":               {1, 2},
+		"no labels":               {3, 4, 5},
+		"/Doing":                  {1},
+		"p1":                      {1, 2},
+		"p4 & !recurring":         {5},
+		"assigned to: me":         {3},
+		"assigned to: others":     {2},
+		"assigned to: sam*":       {2},
+		"assigned":                {2, 3},
+		"search: report":          {1},
+		"#Nothing | #home":        {3, 5},
+		"!(#Home | ##Work) & !p1": {},
+	}
+	for query, expected := range tests {
+		expr, err := ParseFilterQuery(query)
+		require.Nil(t, err, query)
+		ids := []int64{}
+		for _, task := range FilterTasks(tasks, expr, env) {
+			ids = append(ids, task.ID)
+		}
+		assert.Equal(t, expected, ids, query)
+	}
+}
+
+func TestMatchFilterName(t *testing.T) {
+	assert.True(t, matchFilterName("work", "Work"))
+	assert.True(t, matchFilterName("*ork", "Work"))
+	assert.True(t, matchFilterName("W*k", "Work"))
+	assert.True(t, matchFilterName("*", "anything"))
+	assert.True(t, matchFilterName("a*b*c", "aXbYc"))
+	assert.False(t, matchFilterName("a*b*c", "aXcYb"))
+	assert.False(t, matchFilterName("Wor", "Work"))
+}
@@ -1,6 +1,7 @@
 package todoist
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -26,8 +27,63 @@ type LabelParams struct {
 
 // GetAllLabels returns all of the labels for a user's token. https://developer.todoist.com/rest/v1/#get-all-labels
 func GetAllLabels(token string) ([]Label, error) {
+	return clientForToken(token).GetAllLabels()
+}
+
+// GetAllLabelsWithContext is GetAllLabels with a context that can cancel the call or set its deadline
+func GetAllLabelsWithContext(ctx context.Context, token string) ([]Label, error) {
+	return clientForToken(token).GetAllLabelsWithContext(ctx)
+}
+
+// CreateLabel creates a label and requires at least a name. https://developer.todoist.com/rest/v1/#create-a-new-label
+func CreateLabel(token string, input *LabelParams) (*Label, error) {
+	return clientForToken(token).CreateLabel(input)
+}
+
+// CreateLabelWithContext is CreateLabel with a context that can cancel the call or set its deadline
+func CreateLabelWithContext(ctx context.Context, token string, input *LabelParams) (*Label, error) {
+	return clientForToken(token).CreateLabelWithContext(ctx, input)
+}
+
+// GetLabel gets a single label. https://developer.todoist.com/rest/v1/#get-a-label
+func GetLabel(token string, labelID int64) (*Label, error) {
+	return clientForToken(token).GetLabel(labelID)
+}
+
+// GetLabelWithContext is GetLabel with a context that can cancel the call or set its deadline
+func GetLabelWithContext(ctx context.Context, token string, labelID int64) (*Label, error) {
+	return clientForToken(token).GetLabelWithContext(ctx, labelID)
+}
+
+// UpdateLabel updates a label. https://developer.todoist.com/rest/v1/#update-a-label
+func UpdateLabel(token string, labelID int64, input *LabelParams) (*Label, error) {
+	return clientForToken(token).UpdateLabel(labelID, input)
+}
+
+// UpdateLabelWithContext is UpdateLabel with a context that can cancel the call or set its deadline
+func UpdateLabelWithContext(ctx context.Context, token string, labelID int64, input *LabelParams) (*Label, error) {
+	return clientForToken(token).UpdateLabelWithContext(ctx, labelID, input)
+}
+
+// DeleteLabel deletes a label. https://developer.todoist.com/rest/v1/#delete-a-label
+func DeleteLabel(token string, labelID int64) error {
+	return clientForToken(token).DeleteLabel(labelID)
+}
+
+// DeleteLabelWithContext is DeleteLabel with a context that can cancel the call or set its deadline
+func DeleteLabelWithContext(ctx context.Context, token string, labelID int64) error {
+	return clientForToken(token).DeleteLabelWithContext(ctx, labelID)
+}
+
+// GetAllLabels returns all of the labels for a user's token. https://developer.todoist.com/rest/v1/#get-all-labels
+func (c *Client) GetAllLabels() ([]Label, error) {
+	return c.GetAllLabelsWithContext(context.Background())
+}
+
+// GetAllLabelsWithContext is GetAllLabels with a context that can cancel the call or set its deadline
+func (c *Client) GetAllLabelsWithContext(ctx context.Context) ([]Label, error) {
 	labels := []Label{}
-	resp, err := makeCall(token, EndpointNameGetAllLabels, map[string]string{}, nil)
+	resp, err := c.makeCall(ctx, EndpointNameGetAllLabels, map[string]string{}, nil)
 	if err != nil {
 		return labels, err
 	}
@@ -36,14 +92,19 @@ func GetAllLabels(token string) ([]Label, error) {
 }
 
 // CreateLabel creates a label and requires at least a name. https://developer.todoist.com/rest/v1/#create-a-new-label
-func CreateLabel(token string, input *LabelParams) (*Label, error) {
+func (c *Client) CreateLabel(input *LabelParams) (*Label, error) {
+	return c.CreateLabelWithContext(context.Background(), input)
+}
+
+// CreateLabelWithContext is CreateLabel with a context that can cancel the call or set its deadline
+func (c *Client) CreateLabelWithContext(ctx context.Context, input *LabelParams) (*Label, error) {
 	if input == nil {
 		return nil, errors.New("you must provide a valid input with at least a name field")
 	}
 	if input.Name == "" {
 		return nil, errors.New("name is required")
 	}
-	resp, err := makeCall(token, EndpointNameCreateLabel, map[string]string{}, input)
+	resp, err := c.makeCall(ctx, EndpointNameCreateLabel, map[string]string{}, input)
 	if err != nil {
 		return nil, err
 	}
@@ -53,8 +114,13 @@ func CreateLabel(token string, input *LabelParams) (*Label, error) {
 }
 
 // GetLabel gets a single label. https://developer.todoist.com/rest/v1/#get-a-label
-func GetLabel(token string, labelID int64) (*Label, error) {
-	resp, err := makeCall(token, EndpointNameGetLabel, map[string]string{
+func (c *Client) GetLabel(labelID int64) (*Label, error) {
+	return c.GetLabelWithContext(context.Background(), labelID)
+}
+
+// GetLabelWithContext is GetLabel with a context that can cancel the call or set its deadline
+func (c *Client) GetLabelWithContext(ctx context.Context, labelID int64) (*Label, error) {
+	resp, err := c.makeCall(ctx, EndpointNameGetLabel, map[string]string{
 		"id": fmt.Sprintf("%d", labelID),
 	}, nil)
 	if err != nil {
@@ -66,11 +132,16 @@ func GetLabel(token string, labelID int64) (*Label, error) {
 }
 
 // UpdateLabel updates a label. https://developer.todoist.com/rest/v1/#update-a-label
-func UpdateLabel(token string, labelID int64, input *LabelParams) (*Label, error) {
+func (c *Client) UpdateLabel(labelID int64, input *LabelParams) (*Label, error) {
+	return c.UpdateLabelWithContext(context.Background(), labelID, input)
+}
+
+// UpdateLabelWithContext is UpdateLabel with a context that can cancel the call or set its deadline
+func (c *Client) UpdateLabelWithContext(ctx context.Context, labelID int64, input *LabelParams) (*Label, error) {
 	if input == nil {
 		return nil, errors.New("you must provide a valid input")
 	}
-	_, err := makeCall(token, EndpointNameUpdateLabel, map[string]string{
+	_, err := c.makeCall(ctx, EndpointNameUpdateLabel, map[string]string{
 		"id": fmt.Sprintf("%d", labelID),
 	}, input)
 	if err != nil {
@@ -78,12 +149,17 @@ func UpdateLabel(token string, labelID int64, input *LabelParams) (*Label, error
 	}
 	// the update itself returns nothing, so we need to
 	// get it again if we want the updated information
-	return GetLabel(token, labelID)
+	return c.GetLabelWithContext(ctx, labelID)
 }
 
 // DeleteLabel deletes a label. https://developer.todoist.com/rest/v1/#delete-a-label
-func DeleteLabel(token string, labelID int64) error {
-	resp, err := makeCall(token, EndpointNameDeleteLabel, map[string]string{
+func (c *Client) DeleteLabel(labelID int64) error {
+	return c.DeleteLabelWithContext(context.Background(), labelID)
+}
+
+// DeleteLabelWithContext is DeleteLabel with a context that can cancel the call or set its deadline
+func (c *Client) DeleteLabelWithContext(ctx context.Context, labelID int64) error {
+	resp, err := c.makeCall(ctx, EndpointNameDeleteLabel, map[string]string{
 		"id": fmt.Sprintf("%d", labelID),
 	}, nil)
 	if err != nil {
@@ -0,0 +1,463 @@
+package todoist
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// mirrorResourceTypes are the resource types a Mirror keeps
+var mirrorResourceTypes = []SyncResourceType{
+	SyncResourceProjects,
+	SyncResourceItems,
+	SyncResourceSections,
+	SyncResourceLabels,
+	SyncResourceNotes,
+	SyncResourceProjectNotes,
+}
+
+// MirrorSnapshot is the state of a Mirror as it is persisted
+type MirrorSnapshot struct {
+	SyncToken string    `json:"sync_token"`
+	SyncedAt  time.Time `json:"synced_at"`
+	Projects  []Project `json:"projects"`
+	Tasks     []Task    `json:"tasks"`
+	Sections  []Section `json:"sections"`
+	Labels    []Label   `json:"labels"`
+	Comments  []Comment `json:"comments"`
+}
+
+// MirrorStore persists the state of a Mirror, so a restarted process can continue with an incremental sync
+type MirrorStore interface {
+	// LoadMirror returns the saved snapshot, or nil if there is none
+	LoadMirror() (*MirrorSnapshot, error)
+	// SaveMirror saves the snapshot after a sync
+	SaveMirror(snapshot *MirrorSnapshot) error
+}
+
+// FileMirrorStore keeps the snapshot in a JSON file. The file is replaced atomically, so a crash never leaves a partial snapshot behind.
+type FileMirrorStore struct {
+	Path string
+}
+
+// LoadMirror reads the snapshot from the file; a missing file means there is no snapshot yet
+func (s *FileMirrorStore) LoadMirror() (*MirrorSnapshot, error) {
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &MirrorSnapshot{}
+	if err = json.Unmarshal(data, snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// SaveMirror writes the snapshot to the file
+func (s *FileMirrorStore) SaveMirror(snapshot *MirrorSnapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(s.Path, data)
+}
+
+// Mirror is a local copy of an account's active projects, tasks, sections, labels, and comments. Refresh brings it up to date with a
+// sync, a full one the first time and incremental ones after; the queries never touch the network. Completed tasks and archived
+// projects and sections are left out, as they are from the REST API.
+type Mirror struct {
+	client *Client
+	store  MirrorStore
+
+	// refreshLock serializes refreshes, so queries are only blocked while a sync is applied, not while it is in flight
+	refreshLock sync.Mutex
+
+	lock      sync.RWMutex
+	loaded    bool
+	syncToken string
+	syncedAt  time.Time
+	projects  map[int64]Project
+	tasks     map[int64]Task
+	sections  map[int64]Section
+	labels    map[int64]Label
+	comments  map[int64]Comment
+}
+
+// NewMirror creates an empty Mirror. The store may be nil, in which case the mirror is only kept in memory.
+func (c *Client) NewMirror(store MirrorStore) *Mirror {
+	m := &Mirror{
+		client: c,
+		store:  store,
+	}
+	m.reset()
+	return m
+}
+
+// Load reads the mirror from its store, without a network call. It is called by the first Refresh if needed, but can be called earlier
+// to answer queries from the saved state right away.
+func (m *Mirror) Load() error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.load()
+}
+
+// Refresh syncs the changes since the previous refresh, or everything the first time, and saves the new state to the store
+func (m *Mirror) Refresh() error {
+	return m.RefreshWithContext(context.Background())
+}
+
+// RefreshWithContext is Refresh with a context that can cancel the call or set its deadline
+func (m *Mirror) RefreshWithContext(ctx context.Context) error {
+	return m.refresh(ctx, false)
+}
+
+// Rebuild replaces the mirror with a full sync, for when it is suspected to have drifted
+func (m *Mirror) Rebuild() error {
+	return m.RebuildWithContext(context.Background())
+}
+
+// RebuildWithContext is Rebuild with a context that can cancel the call or set its deadline
+func (m *Mirror) RebuildWithContext(ctx context.Context) error {
+	return m.refresh(ctx, true)
+}
+
+func (m *Mirror) refresh(ctx context.Context, full bool) error {
+	m.refreshLock.Lock()
+	defer m.refreshLock.Unlock()
+
+	m.lock.Lock()
+	err := m.load()
+	syncToken := m.syncToken
+	m.lock.Unlock()
+	if err != nil {
+		return err
+	}
+	if full {
+		syncToken = ""
+	}
+
+	result, err := m.client.SyncWithContext(ctx, syncToken, mirrorResourceTypes...)
+	if err != nil {
+		return err
+	}
+
+	m.lock.Lock()
+	m.apply(result, time.Now())
+	snapshot := m.snapshot()
+	m.lock.Unlock()
+
+	if m.store != nil {
+		return m.store.SaveMirror(snapshot)
+	}
+	return nil
+}
+
+// SyncedAt returns when the mirror was last brought up to date, which is the zero time if it never was
+func (m *Mirror) SyncedAt() time.Time {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.syncedAt
+}
+
+// Staleness returns how long ago the mirror was last brought up to date. A mirror that never was is infinitely stale, which is reported
+// as the largest duration.
+func (m *Mirror) Staleness() time.Duration {
+	syncedAt := m.SyncedAt()
+	if syncedAt.IsZero() {
+		return time.Duration(1<<63 - 1)
+	}
+	return time.Since(syncedAt)
+}
+
+// IsStale reports whether the mirror was last brought up to date longer than maxAge ago
+func (m *Mirror) IsStale(maxAge time.Duration) bool {
+	return m.Staleness() > maxAge
+}
+
+// Project returns the project with the id
+func (m *Mirror) Project(id int64) (Project, bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	project, found := m.projects[id]
+	return project, found
+}
+
+// Projects returns every project, in their order
+func (m *Mirror) Projects() []Project {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	projects := make([]Project, 0, len(m.projects))
+	for _, project := range m.projects {
+		projects = append(projects, project)
+	}
+	sort.Slice(projects, func(i, j int) bool {
+		return orderBefore(projects[i].Order, projects[i].ID, projects[j].Order, projects[j].ID)
+	})
+	return projects
+}
+
+// Task returns the task with the id
+func (m *Mirror) Task(id int64) (Task, bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	task, found := m.tasks[id]
+	return task, found
+}
+
+// Tasks returns every task, in their order
+func (m *Mirror) Tasks() []Task {
+	return m.filterTasks(func(*Task) bool { return true })
+}
+
+// TasksByProject returns the tasks of the project, including those in its sections and subtasks
+func (m *Mirror) TasksByProject(projectID int64) []Task {
+	return m.filterTasks(func(t *Task) bool { return t.ProjectID == projectID })
+}
+
+// TasksBySection returns the tasks of the section, including subtasks
+func (m *Mirror) TasksBySection(sectionID int64) []Task {
+	return m.filterTasks(func(t *Task) bool { return t.SectionID == sectionID })
+}
+
+// TasksByLabel returns the tasks with the label
+func (m *Mirror) TasksByLabel(labelID int64) []Task {
+	return m.filterTasks(func(t *Task) bool {
+		for _, id := range t.LabelIDs {
+			if id == labelID {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Subtasks returns the direct subtasks of the task
+func (m *Mirror) Subtasks(parentID int64) []Task {
+	return m.filterTasks(func(t *Task) bool { return t.ParentID == parentID })
+}
+
+func (m *Mirror) filterTasks(keep func(*Task) bool) []Task {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	tasks := []Task{}
+	for id := range m.tasks {
+		task := m.tasks[id]
+		if keep(&task) {
+			tasks = append(tasks, task)
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		return orderBefore(tasks[i].Order, tasks[i].ID, tasks[j].Order, tasks[j].ID)
+	})
+	return tasks
+}
+
+// Section returns the section with the id
+func (m *Mirror) Section(id int64) (Section, bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	section, found := m.sections[id]
+	return section, found
+}
+
+// Sections returns the sections of the project, in their order. If the project id is zero, it returns every section.
+func (m *Mirror) Sections(projectID int64) []Section {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	sections := []Section{}
+	for _, section := range m.sections {
+		if projectID == 0 || section.ProjectID == projectID {
+			sections = append(sections, section)
+		}
+	}
+	sort.Slice(sections, func(i, j int) bool {
+		return orderBefore(sections[i].Order, sections[i].ID, sections[j].Order, sections[j].ID)
+	})
+	return sections
+}
+
+// Label returns the label with the id
+func (m *Mirror) Label(id int64) (Label, bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	label, found := m.labels[id]
+	return label, found
+}
+
+// Labels returns every label, in their order
+func (m *Mirror) Labels() []Label {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	labels := make([]Label, 0, len(m.labels))
+	for _, label := range m.labels {
+		labels = append(labels, label)
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		return orderBefore(labels[i].Order, labels[i].ID, labels[j].Order, labels[j].ID)
+	})
+	return labels
+}
+
+// Comments returns the comments on the task, or, if the task id is zero, on the project, oldest first
+func (m *Mirror) Comments(taskID int64, projectID int64) []Comment {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	comments := []Comment{}
+	for _, comment := range m.comments {
+		if (taskID != 0 && comment.TaskID == taskID) || (taskID == 0 && projectID != 0 && comment.ProjectID == projectID) {
+			comments = append(comments, comment)
+		}
+	}
+	sort.Slice(comments, func(i, j int) bool {
+		if comments[i].Posted != comments[j].Posted {
+			return comments[i].Posted < comments[j].Posted
+		}
+		return comments[i].ID < comments[j].ID
+	})
+	return comments
+}
+
+// orderBefore sorts by order, falling back to the id so the result is stable
+func orderBefore(orderA int64, idA int64, orderB int64, idB int64) bool {
+	if orderA != orderB {
+		return orderA < orderB
+	}
+	return idA < idB
+}
+
+// load reads the store the first time it is needed; the lock must be held
+func (m *Mirror) load() error {
+	if m.loaded {
+		return nil
+	}
+	if m.store != nil {
+		snapshot, err := m.store.LoadMirror()
+		if err != nil {
+			return err
+		}
+		if snapshot != nil {
+			m.restore(snapshot)
+		}
+	}
+	m.loaded = true
+	return nil
+}
+
+// reset empties the mirror; the lock must be held
+func (m *Mirror) reset() {
+	m.syncToken = ""
+	m.syncedAt = time.Time{}
+	m.projects = map[int64]Project{}
+	m.tasks = map[int64]Task{}
+	m.sections = map[int64]Section{}
+	m.labels = map[int64]Label{}
+	m.comments = map[int64]Comment{}
+}
+
+// apply merges the result of a sync into the mirror; the lock must be held
+func (m *Mirror) apply(result *SyncResult, now time.Time) {
+	if result.FullSync {
+		m.reset()
+		m.loaded = true
+	}
+	for i := range result.Projects {
+		p := &result.Projects[i]
+		if p.IsDeleted || p.IsArchived {
+			delete(m.projects, p.ID)
+		} else {
+			m.projects[p.ID] = p.Project
+		}
+	}
+	for i := range result.Tasks {
+		t := &result.Tasks[i]
+		if t.IsDeleted || t.Completed {
+			delete(m.tasks, t.ID)
+		} else {
+			m.tasks[t.ID] = t.Task
+		}
+	}
+	for i := range result.Sections {
+		s := &result.Sections[i]
+		if s.IsDeleted || s.IsArchived {
+			delete(m.sections, s.ID)
+		} else {
+			m.sections[s.ID] = s.Section
+		}
+	}
+	for i := range result.Labels {
+		l := &result.Labels[i]
+		if l.IsDeleted {
+			delete(m.labels, l.ID)
+		} else {
+			m.labels[l.ID] = l.Label
+		}
+	}
+	for i := range result.Comments {
+		c := &result.Comments[i]
+		if c.IsDeleted {
+			delete(m.comments, c.ID)
+		} else {
+			m.comments[c.ID] = c.Comment
+		}
+	}
+	m.syncToken = result.SyncToken
+	m.syncedAt = now
+}
+
+// snapshot copies the mirror for the store; the lock must be held
+func (m *Mirror) snapshot() *MirrorSnapshot {
+	snapshot := &MirrorSnapshot{
+		SyncToken: m.syncToken,
+		SyncedAt:  m.syncedAt,
+		Projects:  make([]Project, 0, len(m.projects)),
+		Tasks:     make([]Task, 0, len(m.tasks)),
+		Sections:  make([]Section, 0, len(m.sections)),
+		Labels:    make([]Label, 0, len(m.labels)),
+		Comments:  make([]Comment, 0, len(m.comments)),
+	}
+	for _, project := range m.projects {
+		snapshot.Projects = append(snapshot.Projects, project)
+	}
+	for _, task := range m.tasks {
+		snapshot.Tasks = append(snapshot.Tasks, task)
+	}
+	for _, section := range m.sections {
+		snapshot.Sections = append(snapshot.Sections, section)
+	}
+	for _, label := range m.labels {
+		snapshot.Labels = append(snapshot.Labels, label)
+	}
+	for _, comment := range m.comments {
+		snapshot.Comments = append(snapshot.Comments, comment)
+	}
+	return snapshot
+}
+
+// restore replaces the mirror with a snapshot; the lock must be held
+func (m *Mirror) restore(snapshot *MirrorSnapshot) {
+	m.reset()
+	m.syncToken = snapshot.SyncToken
+	m.syncedAt = snapshot.SyncedAt
+	for _, project := range snapshot.Projects {
+		m.projects[project.ID] = project
+	}
+	for _, task := range snapshot.Tasks {
+		m.tasks[task.ID] = task
+	}
+	for _, section := range snapshot.Sections {
+		m.sections[section.ID] = section
+	}
+	for _, label := range snapshot.Labels {
+		m.labels[label.ID] = label
+	}
+	for _, comment := range snapshot.Comments {
+		m.comments[comment.ID] = comment
+	}
+}
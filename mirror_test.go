@@ -0,0 +1,86 @@
+package todoist
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMirror(t *testing.T) {
+	server := newSyncTestServer()
+	defer server.Close()
+	client := NewClient("mirror-test", WithBaseURL(server.URL))
+	store := &FileMirrorStore{Path: filepath.Join(t.TempDir(), "mirror.json")}
+
+	mirror := client.NewMirror(store)
+	assert.True(t, mirror.IsStale(time.Hour))
+	require.Nil(t, mirror.Refresh())
+	require.Len(t, server.requests(), 1)
+	assert.Equal(t, "*", server.requests()[0]["sync_token"])
+	assert.False(t, mirror.IsStale(time.Hour))
+	assert.WithinDuration(t, time.Now(), mirror.SyncedAt(), time.Minute)
+
+	projects := mirror.Projects()
+	require.Len(t, projects, 2)
+	assert.Equal(t, "Inbox", projects[0].Name)
+	assert.Equal(t, "Work", projects[1].Name)
+
+	tasks := mirror.TasksByProject(2)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "Write report", tasks[0].Content)
+	assert.Len(t, mirror.TasksBySection(20), 1)
+	assert.Len(t, mirror.TasksByLabel(30), 1)
+	assert.Empty(t, mirror.TasksByLabel(31))
+	assert.Empty(t, mirror.Subtasks(10))
+	assert.Len(t, mirror.Sections(2), 1)
+	assert.Len(t, mirror.Labels(), 1)
+	assert.Len(t, mirror.Comments(10, 0), 1)
+	assert.Len(t, mirror.Comments(0, 2), 1)
+
+	// a restarted process picks up where the first left off
+	restarted := client.NewMirror(store)
+	require.Nil(t, restarted.Load())
+	_, found := restarted.Task(10)
+	assert.True(t, found)
+	require.Nil(t, restarted.Refresh())
+	require.Len(t, server.requests(), 2)
+	assert.Equal(t, "token-1", server.requests()[1]["sync_token"])
+
+	// the completed task is gone, and the archived project never shows up
+	_, found = restarted.Task(10)
+	assert.False(t, found)
+	_, found = restarted.Project(3)
+	assert.False(t, found)
+	assert.Len(t, restarted.Projects(), 2)
+
+	require.Nil(t, restarted.Rebuild())
+	require.Len(t, server.requests(), 3)
+	assert.Equal(t, "*", server.requests()[2]["sync_token"])
+	_, found = restarted.Task(10)
+	assert.True(t, found)
+}
+
+func TestMirrorSubtasksAndOrder(t *testing.T) {
+	mirror := NewClient("mirror-order-test").NewMirror(nil)
+	mirror.apply(&SyncResult{
+		SyncToken: "token",
+		FullSync:  true,
+		Tasks: []SyncedTask{
+			{Task: Task{ID: 3, ProjectID: 1, ParentID: 1, Order: 2}},
+			{Task: Task{ID: 2, ProjectID: 1, ParentID: 1, Order: 1}},
+			{Task: Task{ID: 1, ProjectID: 1, Order: 1}},
+			{Task: Task{ID: 4, ProjectID: 1, Order: 1}, IsDeleted: true},
+		},
+	}, time.Now().Add(-2*time.Hour))
+
+	subtasks := mirror.Subtasks(1)
+	require.Len(t, subtasks, 2)
+	assert.Equal(t, int64(2), subtasks[0].ID)
+	assert.Equal(t, int64(3), subtasks[1].ID)
+	assert.Len(t, mirror.Tasks(), 3)
+	assert.True(t, mirror.IsStale(time.Hour))
+	assert.False(t, mirror.IsStale(3*time.Hour))
+}
@@ -0,0 +1,197 @@
+package todoist
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"gopkg.in/resty.v1"
+)
+
+// OAuthScope is a permission an app asks the user for during the authorization flow. https://developer.todoist.com/guides/#authorization
+type OAuthScope string
+
+const (
+	// OAuthScopeTaskAdd allows adding tasks, and nothing else
+	OAuthScopeTaskAdd OAuthScope = "task:add"
+	// OAuthScopeDataRead allows reading all of the user's data
+	OAuthScopeDataRead OAuthScope = "data:read"
+	// OAuthScopeDataReadWrite allows reading and changing all of the user's data
+	OAuthScopeDataReadWrite OAuthScope = "data:read_write"
+	// OAuthScopeDataDelete allows deleting tasks, labels, and filters
+	OAuthScopeDataDelete OAuthScope = "data:delete"
+	// OAuthScopeProjectDelete allows deleting projects
+	OAuthScopeProjectDelete OAuthScope = "project:delete"
+)
+
+const (
+	defaultOAuthAuthorizeURL = "https://todoist.com/oauth/authorize"
+	defaultOAuthTokenURL     = "https://todoist.com/oauth/access_token"
+	defaultOAuthRevokeURL    = "https://api.todoist.com/sync/v8/access_tokens/revoke"
+
+	// the OAuth calls do not live in the endpoints table, but still name themselves in an APIError
+
+	endpointNameOAuthAccessToken = "OAuthAccessToken"
+	endpointNameOAuthRevoke      = "OAuthRevoke"
+)
+
+// ErrOAuthStateMismatch means the state of an authorization callback is not the one the flow was started with, which may be a CSRF attempt
+var ErrOAuthStateMismatch = errors.New("todoist: oauth state mismatch")
+
+// OAuthConfig holds an app's credentials and drives the authorization code flow. The URLs default to Todoist's, but can be pointed at a
+// local stand-in for testing.
+type OAuthConfig struct {
+	ClientID     string
+	ClientSecret string
+	Scopes       []OAuthScope
+
+	AuthorizeURL string       // defaults to https://todoist.com/oauth/authorize
+	TokenURL     string       // defaults to https://todoist.com/oauth/access_token
+	RevokeURL    string       // defaults to https://api.todoist.com/sync/v8/access_tokens/revoke
+	HTTPClient   *http.Client // defaults to a new http.Client
+}
+
+// OAuthToken is the result of a successful authorization. The AccessToken is the token every call of the SDK takes.
+type OAuthToken struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+}
+
+// NewClient creates a Client for the user that authorized the app
+func (t *OAuthToken) NewClient(opts ...ClientOption) *Client {
+	return NewClient(t.AccessToken, opts...)
+}
+
+// OAuthCallbackError is returned by ValidateCallback when the user, or Todoist, refused the authorization
+type OAuthCallbackError struct {
+	Code string // such as access_denied or invalid_scope
+}
+
+func (e *OAuthCallbackError) Error() string {
+	return fmt.Sprintf("todoist: authorization failed: %s", e.Code)
+}
+
+// NewOAuthState returns a random value to use as the state of an authorization flow. Store it with the user's session, pass it to
+// AuthCodeURL, and compare it in ValidateCallback.
+func NewOAuthState() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// AuthCodeURL returns the URL to send the user to so they can authorize the app
+func (o *OAuthConfig) AuthCodeURL(state string) string {
+	scopes := make([]string, len(o.Scopes))
+	for i := range o.Scopes {
+		scopes[i] = string(o.Scopes[i])
+	}
+	query := url.Values{}
+	query.Set("client_id", o.ClientID)
+	query.Set("scope", strings.Join(scopes, ","))
+	query.Set("state", state)
+	return oauthURL(o.AuthorizeURL, defaultOAuthAuthorizeURL) + "?" + query.Encode()
+}
+
+// ValidateCallback checks the request Todoist redirected the user back with and returns the authorization code. The state must be the
+// one the flow was started with.
+func (o *OAuthConfig) ValidateCallback(r *http.Request, expectedState string) (string, error) {
+	query := r.URL.Query()
+	if expectedState == "" || subtle.ConstantTimeCompare([]byte(query.Get("state")), []byte(expectedState)) != 1 {
+		return "", ErrOAuthStateMismatch
+	}
+	if code := query.Get("error"); code != "" {
+		return "", &OAuthCallbackError{Code: code}
+	}
+	code := query.Get("code")
+	if code == "" {
+		return "", errors.New("the callback has no code")
+	}
+	return code, nil
+}
+
+// Exchange trades an authorization code for an access token
+func (o *OAuthConfig) Exchange(code string) (*OAuthToken, error) {
+	return o.ExchangeWithContext(context.Background(), code)
+}
+
+// ExchangeWithContext is Exchange with a context that can cancel the call or set its deadline
+func (o *OAuthConfig) ExchangeWithContext(ctx context.Context, code string) (*OAuthToken, error) {
+	if code == "" {
+		return nil, errors.New("code is required")
+	}
+	resp, err := o.post(endpointNameOAuthAccessToken, oauthURL(o.TokenURL, defaultOAuthTokenURL), o.newRequest(ctx).SetFormData(map[string]string{
+		"client_id":     o.ClientID,
+		"client_secret": o.ClientSecret,
+		"code":          code,
+	}))
+	if err != nil {
+		return nil, err
+	}
+	token := &OAuthToken{}
+	if err = json.Unmarshal(resp.Body(), token); err != nil {
+		return nil, err
+	}
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("no access token in the response: %s", string(resp.Body()))
+	}
+	return token, nil
+}
+
+// Revoke invalidates an access token, such as when a user disconnects the app
+func (o *OAuthConfig) Revoke(accessToken string) error {
+	return o.RevokeWithContext(context.Background(), accessToken)
+}
+
+// RevokeWithContext is Revoke with a context that can cancel the call or set its deadline
+func (o *OAuthConfig) RevokeWithContext(ctx context.Context, accessToken string) error {
+	if accessToken == "" {
+		return errors.New("access token is required")
+	}
+	_, err := o.post(endpointNameOAuthRevoke, oauthURL(o.RevokeURL, defaultOAuthRevokeURL), o.newRequest(ctx).SetBody(map[string]string{
+		"client_id":     o.ClientID,
+		"client_secret": o.ClientSecret,
+		"access_token":  accessToken,
+	}))
+	return err
+}
+
+func (o *OAuthConfig) newRequest(ctx context.Context) *resty.Request {
+	httpClient := o.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+	return resty.NewWithClient(httpClient).R().SetContext(ctx)
+}
+
+func (o *OAuthConfig) post(endpointName string, target string, r *resty.Request) (*resty.Response, error) {
+	resp, err := r.Post(target)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() < http.StatusOK || resp.StatusCode() >= http.StatusMultipleChoices {
+		return nil, &APIError{
+			StatusCode: resp.StatusCode(),
+			Endpoint:   endpointName,
+			Method:     http.MethodPost,
+			Body:       string(resp.Body()),
+			RequestID:  resp.Header().Get("X-Request-Id"),
+		}
+	}
+	return resp, nil
+}
+
+func oauthURL(configured string, fallback string) string {
+	if configured != "" {
+		return configured
+	}
+	return fallback
+}
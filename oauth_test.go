@@ -0,0 +1,125 @@
+package todoist
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOAuthAuthCodeURL(t *testing.T) {
+	o := &OAuthConfig{
+		ClientID: "client-1",
+		Scopes:   []OAuthScope{OAuthScopeDataReadWrite, OAuthScopeDataDelete},
+	}
+	state, err := NewOAuthState()
+	require.Nil(t, err)
+	assert.NotEqual(t, "", state)
+	other, _ := NewOAuthState()
+	assert.NotEqual(t, state, other)
+
+	parsed, err := url.Parse(o.AuthCodeURL(state))
+	require.Nil(t, err)
+	assert.Equal(t, "todoist.com", parsed.Host)
+	assert.Equal(t, "/oauth/authorize", parsed.Path)
+	assert.Equal(t, "client-1", parsed.Query().Get("client_id"))
+	assert.Equal(t, "data:read_write,data:delete", parsed.Query().Get("scope"))
+	assert.Equal(t, state, parsed.Query().Get("state"))
+
+	o.AuthorizeURL = "http://localhost:8080/authorize"
+	assert.Contains(t, o.AuthCodeURL(state), "http://localhost:8080/authorize?")
+}
+
+func TestOAuthValidateCallback(t *testing.T) {
+	o := &OAuthConfig{}
+	callback := func(query string) *http.Request {
+		return httptest.NewRequest(http.MethodGet, "/oauth/callback?"+query, nil)
+	}
+
+	code, err := o.ValidateCallback(callback("code=abc&state=xyz"), "xyz")
+	assert.Nil(t, err)
+	assert.Equal(t, "abc", code)
+
+	_, err = o.ValidateCallback(callback("code=abc&state=forged"), "xyz")
+	assert.True(t, errors.Is(err, ErrOAuthStateMismatch))
+	_, err = o.ValidateCallback(callback("code=abc"), "")
+	assert.True(t, errors.Is(err, ErrOAuthStateMismatch))
+	_, err = o.ValidateCallback(callback("state=xyz"), "xyz")
+	assert.NotNil(t, err)
+
+	_, err = o.ValidateCallback(callback("error=access_denied&state=xyz"), "xyz")
+	callbackErr := &OAuthCallbackError{}
+	require.True(t, errors.As(err, &callbackErr))
+	assert.Equal(t, "access_denied", callbackErr.Code)
+}
+
+func TestOAuthExchangeAndRevoke(t *testing.T) {
+	var lock sync.Mutex
+	revoked := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/oauth/access_token":
+			if err := r.ParseForm(); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if r.PostForm.Get("client_id") != "client-1" || r.PostForm.Get("client_secret") != "secret-1" || r.PostForm.Get("code") != "good-code" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error": "bad_authorization_code"}`))
+				return
+			}
+			w.Write([]byte(`{"access_token": "user-token", "token_type": "Bearer"}`))
+		case "/sync/v8/access_tokens/revoke":
+			body := map[string]string{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if body["client_secret"] != "secret-1" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			lock.Lock()
+			revoked = body["access_token"]
+			lock.Unlock()
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	o := &OAuthConfig{
+		ClientID:     "client-1",
+		ClientSecret: "secret-1",
+		TokenURL:     server.URL + "/oauth/access_token",
+		RevokeURL:    server.URL + "/sync/v8/access_tokens/revoke",
+	}
+	token, err := o.ExchangeWithContext(context.Background(), "good-code")
+	require.Nil(t, err)
+	assert.Equal(t, "user-token", token.AccessToken)
+	assert.Equal(t, "Bearer", token.TokenType)
+	assert.Equal(t, "user-token", token.NewClient().Token())
+
+	_, err = o.Exchange("")
+	assert.NotNil(t, err)
+	_, err = o.Exchange("bad-code")
+	assert.True(t, errors.Is(err, ErrBadRequest))
+	apiErr := &APIError{}
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, endpointNameOAuthAccessToken, apiErr.Endpoint)
+
+	assert.Nil(t, o.RevokeWithContext(context.Background(), "user-token"))
+	lock.Lock()
+	assert.Equal(t, "user-token", revoked)
+	lock.Unlock()
+	assert.NotNil(t, o.Revoke(""))
+	o.ClientSecret = "wrong"
+	assert.True(t, errors.Is(o.Revoke("user-token"), ErrForbidden))
+}
@@ -1,6 +1,7 @@
 package todoist
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -23,6 +24,7 @@ type Project struct {
 	URL          string `json:"url" db:"url"`
 	TeamInbox    bool   `json:"team_inbox" db:"team_inbox"`
 	ParentID     int64  `json:"parent_id" db:"parent_id"`
+	IsArchived   bool   `json:"is_archived" db:"is_archived"`
 }
 
 // ProjectParams are the fields set during creating or updating a project
@@ -35,8 +37,73 @@ type ProjectParams struct {
 
 // GetAllProjects returns all of the project for a user's token. https://developer.todoist.com/rest/v1/#get-all-projects
 func GetAllProjects(token string) ([]Project, error) {
+	return clientForToken(token).GetAllProjects()
+}
+
+// GetAllProjectsWithContext is GetAllProjects with a context that can cancel the call or set its deadline
+func GetAllProjectsWithContext(ctx context.Context, token string) ([]Project, error) {
+	return clientForToken(token).GetAllProjectsWithContext(ctx)
+}
+
+// CreateProject creates a new project for the user. Tasks belong to projects and require, at a minimum, a name. https://developer.todoist.com/rest/v1/#create-a-new-project
+func CreateProject(token string, input *ProjectParams) (*Project, error) {
+	return clientForToken(token).CreateProject(input)
+}
+
+// CreateProjectWithContext is CreateProject with a context that can cancel the call or set its deadline
+func CreateProjectWithContext(ctx context.Context, token string, input *ProjectParams) (*Project, error) {
+	return clientForToken(token).CreateProjectWithContext(ctx, input)
+}
+
+// CreateTestProject creates a simple test project to be used in tests
+func CreateTestProject(token string) (*Project, error) {
+	return clientForToken(token).CreateTestProject()
+}
+
+// CreateTestProjectWithContext is CreateTestProject with a context that can cancel the call or set its deadline
+func CreateTestProjectWithContext(ctx context.Context, token string) (*Project, error) {
+	return clientForToken(token).CreateTestProjectWithContext(ctx)
+}
+
+// GetProject gets a single project by its id. https://developer.todoist.com/rest/v1/#get-a-project
+func GetProject(token string, projectID int64) (*Project, error) {
+	return clientForToken(token).GetProject(projectID)
+}
+
+// GetProjectWithContext is GetProject with a context that can cancel the call or set its deadline
+func GetProjectWithContext(ctx context.Context, token string, projectID int64) (*Project, error) {
+	return clientForToken(token).GetProjectWithContext(ctx, projectID)
+}
+
+// UpdateProject updates a project. Currently, only name, color, and favorite are supported. https://developer.todoist.com/rest/v1/#update-a-project
+func UpdateProject(token string, projectID int64, params *ProjectParams) (*Project, error) {
+	return clientForToken(token).UpdateProject(projectID, params)
+}
+
+// UpdateProjectWithContext is UpdateProject with a context that can cancel the call or set its deadline
+func UpdateProjectWithContext(ctx context.Context, token string, projectID int64, params *ProjectParams) (*Project, error) {
+	return clientForToken(token).UpdateProjectWithContext(ctx, projectID, params)
+}
+
+// DeleteProject deletes a project. https://developer.todoist.com/rest/v1/#delete-a-project
+func DeleteProject(token string, projectID int64) error {
+	return clientForToken(token).DeleteProject(projectID)
+}
+
+// DeleteProjectWithContext is DeleteProject with a context that can cancel the call or set its deadline
+func DeleteProjectWithContext(ctx context.Context, token string, projectID int64) error {
+	return clientForToken(token).DeleteProjectWithContext(ctx, projectID)
+}
+
+// GetAllProjects returns all of the projects for the client's user. https://developer.todoist.com/rest/v1/#get-all-projects
+func (c *Client) GetAllProjects() ([]Project, error) {
+	return c.GetAllProjectsWithContext(context.Background())
+}
+
+// GetAllProjectsWithContext is GetAllProjects with a context that can cancel the call or set its deadline
+func (c *Client) GetAllProjectsWithContext(ctx context.Context) ([]Project, error) {
 	projects := []Project{}
-	resp, err := makeCall(token, EndpointNameGetProjects, map[string]string{}, nil)
+	resp, err := c.makeCall(ctx, EndpointNameGetProjects, map[string]string{}, nil)
 	if err != nil {
 		return projects, err
 	}
@@ -45,14 +112,19 @@ func GetAllProjects(token string) ([]Project, error) {
 }
 
 // CreateProject creates a new project for the user. Tasks belong to projects and require, at a minimum, a name. https://developer.todoist.com/rest/v1/#create-a-new-project
-func CreateProject(token string, input *ProjectParams) (*Project, error) {
+func (c *Client) CreateProject(input *ProjectParams) (*Project, error) {
+	return c.CreateProjectWithContext(context.Background(), input)
+}
+
+// CreateProjectWithContext is CreateProject with a context that can cancel the call or set its deadline
+func (c *Client) CreateProjectWithContext(ctx context.Context, input *ProjectParams) (*Project, error) {
 	if input == nil {
 		return nil, errors.New("you must provide a valid input with at least a name field")
 	}
 	if input.Name == nil {
 		return nil, errors.New("name is required")
 	}
-	resp, err := makeCall(token, EndpointNameCreateProject, map[string]string{}, input)
+	resp, err := c.makeCall(ctx, EndpointNameCreateProject, map[string]string{}, input)
 	if err != nil {
 		return nil, err
 	}
@@ -62,17 +134,27 @@ func CreateProject(token string, input *ProjectParams) (*Project, error) {
 }
 
 // CreateTestProject creates a simple test project to be used in tests
-func CreateTestProject(token string) (*Project, error) {
+func (c *Client) CreateTestProject() (*Project, error) {
+	return c.CreateTestProjectWithContext(context.Background())
+}
+
+// CreateTestProjectWithContext is CreateTestProject with a context that can cancel the call or set its deadline
+func (c *Client) CreateTestProjectWithContext(ctx context.Context) (*Project, error) {
 	r := rand.Int63n((999999999))
 	input := ProjectParams{
 		Name: String(fmt.Sprintf("Test Project %d", r)),
 	}
-	return CreateProject(token, &input)
+	return c.CreateProjectWithContext(ctx, &input)
 }
 
 // GetProject gets a single project by its id. https://developer.todoist.com/rest/v1/#get-a-project
-func GetProject(token string, projectID int64) (*Project, error) {
-	resp, err := makeCall(token, EndpointNameGetProject, map[string]string{
+func (c *Client) GetProject(projectID int64) (*Project, error) {
+	return c.GetProjectWithContext(context.Background(), projectID)
+}
+
+// GetProjectWithContext is GetProject with a context that can cancel the call or set its deadline
+func (c *Client) GetProjectWithContext(ctx context.Context, projectID int64) (*Project, error) {
+	resp, err := c.makeCall(ctx, EndpointNameGetProject, map[string]string{
 		"id": fmt.Sprintf("%d", projectID),
 	}, nil)
 	if err != nil {
@@ -84,11 +166,16 @@ func GetProject(token string, projectID int64) (*Project, error) {
 }
 
 // UpdateProject updates a project. Currently, only name, color, and favorite are supported. https://developer.todoist.com/rest/v1/#update-a-project
-func UpdateProject(token string, projectID int64, params *ProjectParams) (*Project, error) {
+func (c *Client) UpdateProject(projectID int64, params *ProjectParams) (*Project, error) {
+	return c.UpdateProjectWithContext(context.Background(), projectID, params)
+}
+
+// UpdateProjectWithContext is UpdateProject with a context that can cancel the call or set its deadline
+func (c *Client) UpdateProjectWithContext(ctx context.Context, projectID int64, params *ProjectParams) (*Project, error) {
 	if params == nil {
 		return nil, errors.New("you must pass in a valid input")
 	}
-	_, err := makeCall(token, EndpointNameUpdateProject, map[string]string{
+	_, err := c.makeCall(ctx, EndpointNameUpdateProject, map[string]string{
 		"id": fmt.Sprintf("%d", projectID),
 	}, params)
 	if err != nil {
@@ -96,12 +183,17 @@ func UpdateProject(token string, projectID int64, params *ProjectParams) (*Proje
 	}
 	// the update itself returns nothing, so we need to
 	// get it again if we want the updated information
-	return GetProject(token, projectID)
+	return c.GetProjectWithContext(ctx, projectID)
 }
 
 // DeleteProject deletes a project. https://developer.todoist.com/rest/v1/#delete-a-project
-func DeleteProject(token string, projectID int64) error {
-	resp, err := makeCall(token, EndpointNameDeleteProject, map[string]string{
+func (c *Client) DeleteProject(projectID int64) error {
+	return c.DeleteProjectWithContext(context.Background(), projectID)
+}
+
+// DeleteProjectWithContext is DeleteProject with a context that can cancel the call or set its deadline
+func (c *Client) DeleteProjectWithContext(ctx context.Context, projectID int64) error {
+	resp, err := c.makeCall(ctx, EndpointNameDeleteProject, map[string]string{
 		"id": fmt.Sprintf("%d", projectID),
 	}, nil)
 	if err != nil {
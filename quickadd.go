@@ -0,0 +1,64 @@
+package todoist
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+)
+
+// QuickAddOptions are the extras of a quick add, besides the text itself
+type QuickAddOptions struct {
+	Note         string // added as a comment on the task
+	Reminder     string // when to remind, in free form text such as "tomorrow 4pm"
+	AutoReminder bool   // add the user's default reminder if the task is due at a time
+}
+
+// QuickAddTask creates a task from text the way the app's quick add does, so "Pay invoice tomorrow 5pm #Finance @urgent p1" becomes a
+// task due tomorrow at 5pm in the Finance project, with the urgent label and the highest priority. The options may be nil.
+// https://developer.todoist.com/sync/v8/#quick-add-an-item
+func QuickAddTask(token string, text string, opts *QuickAddOptions) (*Task, error) {
+	return clientForToken(token).QuickAddTask(text, opts)
+}
+
+// QuickAddTaskWithContext is QuickAddTask with a context that can cancel the call or set its deadline
+func QuickAddTaskWithContext(ctx context.Context, token string, text string, opts *QuickAddOptions) (*Task, error) {
+	return clientForToken(token).QuickAddTaskWithContext(ctx, text, opts)
+}
+
+// QuickAddTask creates a task from text the way the app's quick add does, so "Pay invoice tomorrow 5pm #Finance @urgent p1" becomes a
+// task due tomorrow at 5pm in the Finance project, with the urgent label and the highest priority. The options may be nil.
+// https://developer.todoist.com/sync/v8/#quick-add-an-item
+func (c *Client) QuickAddTask(text string, opts *QuickAddOptions) (*Task, error) {
+	return c.QuickAddTaskWithContext(context.Background(), text, opts)
+}
+
+// QuickAddTaskWithContext is QuickAddTask with a context that can cancel the call or set its deadline
+func (c *Client) QuickAddTaskWithContext(ctx context.Context, text string, opts *QuickAddOptions) (*Task, error) {
+	if text == "" {
+		return nil, errors.New("text is required")
+	}
+	data := map[string]string{
+		"text": text,
+	}
+	if opts != nil {
+		if opts.Note != "" {
+			data["note"] = opts.Note
+		}
+		if opts.Reminder != "" {
+			data["reminder"] = opts.Reminder
+		}
+		if opts.AutoReminder {
+			data["auto_reminder"] = "true"
+		}
+	}
+	resp, err := c.makeCall(ctx, EndpointNameQuickAddTask, map[string]string{}, data)
+	if err != nil {
+		return nil, err
+	}
+	item := syncItem{}
+	if err = json.Unmarshal(resp.Body, &item); err != nil {
+		return nil, err
+	}
+	task := item.toTask()
+	return &task, nil
+}
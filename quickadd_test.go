@@ -0,0 +1,49 @@
+package todoist
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuickAddTask(t *testing.T) {
+	fake, client := newFakeTodoistClient(t)
+	fake.seed("projects", map[string]interface{}{"id": 2, "name": "Finance"})
+	fake.seed("labels", map[string]interface{}{"id": 30, "name": "urgent"})
+
+	_, err := client.QuickAddTask("", nil)
+	assert.NotNil(t, err)
+
+	task, err := client.QuickAddTask("Pay invoice #Finance @urgent p1", &QuickAddOptions{
+		Note:         "Invoice attached",
+		Reminder:     "tomorrow 4pm",
+		AutoReminder: true,
+	})
+	require.Nil(t, err)
+	forms := fake.calls("quick/add")
+	require.Len(t, forms, 1)
+	assert.Equal(t, url.Values{
+		"text":          {"Pay invoice #Finance @urgent p1"},
+		"note":          {"Invoice attached"},
+		"reminder":      {"tomorrow 4pm"},
+		"auto_reminder": {"true"},
+	}, forms[0])
+
+	assert.Equal(t, fake.nextID, task.ID)
+	assert.Equal(t, int64(2), task.ProjectID)
+	assert.Equal(t, "Pay invoice", task.Content)
+	assert.Equal(t, PriorityUrgent, task.Priority)
+	assert.Equal(t, []int64{30}, task.LabelIDs)
+	assert.False(t, task.Completed)
+
+	// the task is there for the REST API too
+	fetched, err := client.GetActiveTask(task.ID)
+	require.Nil(t, err)
+	assert.Equal(t, "Pay invoice", fetched.Content)
+
+	_, err = client.QuickAddTask("Call mom", nil)
+	require.Nil(t, err)
+	assert.Equal(t, url.Values{"text": {"Call mom"}}, fake.calls("quick/add")[1])
+}
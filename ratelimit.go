@@ -0,0 +1,172 @@
+package todoist
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimit is the request budget a Client keeps to for a token. Every Client using the same token and RateLimit shares one budget, which
+// is refilled at Requests per Period and holds at most Burst unused requests. Calls wait for budget before they are sent, so a batch job slows down
+// instead of receiving 429 responses.
+type RateLimit struct {
+	Requests int           // how many requests are allowed per Period; 0 or less disables the limit
+	Period   time.Duration // the window the Requests are spread over
+	Burst    int           // how many requests may be sent back to back; defaults to Requests
+}
+
+// DefaultRateLimit matches Todoist's documented quota of 450 requests per user within a 15 minute period
+func DefaultRateLimit() RateLimit {
+	return RateLimit{
+		Requests: 450,
+		Period:   15 * time.Minute,
+	}
+}
+
+// WithRateLimit sets the request budget of the Client. Pass RateLimit{} to disable the limit. The budget is shared with the other Clients
+// using the same token and the same limit; a Client with a different limit keeps a budget of its own.
+func WithRateLimit(limit RateLimit) ClientOption {
+	return func(c *Client) {
+		c.rateLimit = limit
+	}
+}
+
+// RemainingRequests reports how many requests the Client may send right now without waiting, and whether the Client is rate limited at
+// all. Batch jobs can use it to pace themselves.
+func (c *Client) RemainingRequests() (int, bool) {
+	limiter := c.rateLimiter()
+	if limiter == nil {
+		return 0, false
+	}
+	return limiter.remaining(), true
+}
+
+// rateLimiter returns the limiter shared by every Client with the same token and limit, or nil if the Client is not rate limited
+func (c *Client) rateLimiter() *tokenBucket {
+	if c.rateLimit.Requests <= 0 || c.rateLimit.Period <= 0 {
+		return nil
+	}
+	now := time.Now()
+	key := rateLimitKey{token: c.token, limit: c.rateLimit}
+	rateLimiters.lock.Lock()
+	defer rateLimiters.lock.Unlock()
+	if now.Sub(rateLimiters.swept) >= rateLimiterSweepInterval {
+		rateLimiters.sweep(now)
+	}
+	limiter, found := rateLimiters.buckets[key]
+	if !found {
+		limiter = newTokenBucket(c.rateLimit)
+		rateLimiters.buckets[key] = limiter
+	}
+	limiter.used = now
+	return limiter
+}
+
+// rateLimiterSweepInterval is how often the unused limiters are looked for, so the sweep's cost is spread over many calls
+const rateLimiterSweepInterval = time.Minute
+
+// rateLimitKey identifies a shared limiter
+type rateLimitKey struct {
+	token string
+	limit RateLimit
+}
+
+// rateLimiters holds the limiter of each token and limit in use
+var rateLimiters = rateLimiterRegistry{
+	buckets: map[rateLimitKey]*tokenBucket{},
+}
+
+type rateLimiterRegistry struct {
+	lock    sync.Mutex
+	buckets map[rateLimitKey]*tokenBucket
+	swept   time.Time
+}
+
+// sweep drops the limiters that are full and have not been used for a whole period. A new limiter starts out full, so dropping them
+// changes no budget. The lock must be held.
+func (r *rateLimiterRegistry) sweep(now time.Time) {
+	for key, bucket := range r.buckets {
+		if now.Sub(bucket.used) >= key.limit.Period && bucket.full(now) {
+			delete(r.buckets, key)
+		}
+	}
+	r.swept = now
+}
+
+// tokenBucket is a token bucket limiter; each request takes one token, and tokens are added back at a fixed rate
+type tokenBucket struct {
+	lock     sync.Mutex
+	perToken time.Duration
+	burst    float64
+	tokens   float64
+	last     time.Time
+	used     time.Time // when a Client last looked the limiter up; guarded by the registry's lock
+}
+
+func newTokenBucket(limit RateLimit) *tokenBucket {
+	burst := limit.Burst
+	if burst <= 0 {
+		burst = limit.Requests
+	}
+	return &tokenBucket{
+		perToken: limit.Period / time.Duration(limit.Requests),
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		last:     time.Now(),
+	}
+}
+
+// refill adds the tokens earned since the last refill; the lock must be held
+func (b *tokenBucket) refill(now time.Time) {
+	if now.Before(b.last) {
+		return
+	}
+	if b.perToken > 0 {
+		b.tokens += float64(now.Sub(b.last)) / float64(b.perToken)
+	} else {
+		b.tokens = b.burst
+	}
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+}
+
+// wait blocks until a token is available and takes it, or returns the context's error if it ends first
+func (b *tokenBucket) wait(ctx context.Context) error {
+	for {
+		b.lock.Lock()
+		b.refill(time.Now())
+		if b.tokens >= 1 {
+			b.tokens--
+			b.lock.Unlock()
+			return nil
+		}
+		wait := time.Duration((1 - b.tokens) * float64(b.perToken))
+		b.lock.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// full reports whether the bucket has refilled to its burst
+func (b *tokenBucket) full(now time.Time) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.refill(now)
+	return b.tokens >= b.burst
+}
+
+// remaining returns the whole tokens currently available
+func (b *tokenBucket) remaining() int {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.refill(time.Now())
+	return int(b.tokens)
+}
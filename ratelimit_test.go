@@ -0,0 +1,116 @@
+package todoist
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitSharedPerToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+	limit := WithRateLimit(RateLimit{
+		Requests: 10,
+		Period:   time.Second,
+		Burst:    2,
+	})
+	first := NewClient("rate-limit-shared", WithBaseURL(server.URL), limit)
+	second := NewClient("rate-limit-shared", WithBaseURL(server.URL), limit)
+	other := NewClient("rate-limit-other", WithBaseURL(server.URL), limit)
+
+	remaining, limited := first.RemainingRequests()
+	assert.True(t, limited)
+	assert.Equal(t, 2, remaining)
+
+	_, err := first.GetAllLabels()
+	assert.Nil(t, err)
+	_, err = second.GetAllLabels()
+	assert.Nil(t, err)
+	remaining, _ = first.RemainingRequests()
+	assert.Zero(t, remaining)
+	remaining, _ = other.RemainingRequests()
+	assert.Equal(t, 2, remaining)
+
+	// the budget is spent, so the next call waits for the refill of about 100ms
+	start := time.Now()
+	_, err = second.GetAllLabels()
+	assert.Nil(t, err)
+	assert.True(t, time.Since(start) >= 50*time.Millisecond)
+
+	// waiting stops with the context
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = first.GetAllLabelsWithContext(ctx)
+	assert.True(t, errors.Is(err, context.Canceled))
+}
+
+func TestRateLimitPerLimit(t *testing.T) {
+	strict := NewClient("rate-limit-per-limit", WithRateLimit(RateLimit{Requests: 2, Period: time.Minute}))
+	loose := NewClient("rate-limit-per-limit", WithRateLimit(RateLimit{Requests: 50, Period: time.Minute}))
+	strictAgain := NewClient("rate-limit-per-limit", WithRateLimit(RateLimit{Requests: 2, Period: time.Minute}))
+
+	remaining, _ := strict.RemainingRequests()
+	assert.Equal(t, 2, remaining)
+	remaining, _ = loose.RemainingRequests()
+	assert.Equal(t, 50, remaining)
+	assert.Same(t, strict.rateLimiter(), strictAgain.rateLimiter())
+	assert.NotSame(t, strict.rateLimiter(), loose.rateLimiter())
+}
+
+func TestRateLimiterSweep(t *testing.T) {
+	limit := RateLimit{Requests: 4, Period: time.Minute}
+	idle := NewClient("rate-limit-sweep-idle", WithRateLimit(limit))
+	busy := NewClient("rate-limit-sweep-busy", WithRateLimit(limit))
+	idleBucket := idle.rateLimiter()
+	busyBucket := busy.rateLimiter()
+	busyBucket.tokens = 1
+
+	rateLimiters.lock.Lock()
+	rateLimiters.sweep(time.Now().Add(30 * time.Second))
+	_, kept := rateLimiters.buckets[rateLimitKey{token: idle.token, limit: limit}]
+	assert.True(t, kept, "used within the period")
+
+	idleBucket.used = idleBucket.used.Add(-time.Hour)
+	busyBucket.used = busyBucket.used.Add(-time.Hour)
+	rateLimiters.sweep(time.Now())
+	_, kept = rateLimiters.buckets[rateLimitKey{token: idle.token, limit: limit}]
+	assert.False(t, kept, "full and idle")
+	_, kept = rateLimiters.buckets[rateLimitKey{token: busy.token, limit: limit}]
+	assert.True(t, kept, "still refilling")
+	rateLimiters.lock.Unlock()
+}
+
+func TestRateLimitDisabled(t *testing.T) {
+	client := NewClient("rate-limit-disabled", WithRateLimit(RateLimit{}))
+	assert.Nil(t, client.rateLimiter())
+	_, limited := client.RemainingRequests()
+	assert.False(t, limited)
+
+	client = NewClient("rate-limit-default")
+	remaining, limited := client.RemainingRequests()
+	assert.True(t, limited)
+	assert.Equal(t, DefaultRateLimit().Requests, remaining)
+}
+
+func TestTokenBucketRefill(t *testing.T) {
+	bucket := newTokenBucket(RateLimit{
+		Requests: 4,
+		Period:   time.Minute,
+	})
+	require.Equal(t, 4, bucket.remaining())
+	now := bucket.last
+	bucket.tokens = 0
+	bucket.refill(now.Add(30 * time.Second))
+	assert.Equal(t, 2, int(bucket.tokens))
+	// never more than the burst
+	bucket.refill(now.Add(time.Hour))
+	assert.Equal(t, 4, int(bucket.tokens))
+}
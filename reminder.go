@@ -0,0 +1,398 @@
+package todoist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ReminderType is how a reminder is triggered. https://developer.todoist.com/sync/v8/#reminders
+type ReminderType string
+
+const (
+	ReminderTypeRelative ReminderType = "relative" // a number of minutes before the task is due
+	ReminderTypeAbsolute ReminderType = "absolute" // at a date and time of its own
+	ReminderTypeLocation ReminderType = "location" // on arriving at or leaving a place
+)
+
+// LocationTrigger is whether a location reminder fires on arriving at the place or on leaving it
+type LocationTrigger string
+
+const (
+	LocationTriggerOnEnter LocationTrigger = "on_enter"
+	LocationTriggerOnLeave LocationTrigger = "on_leave"
+)
+
+// ErrReminderNeedsDueTime means a relative reminder was put on a task that has no due time to count back from
+var ErrReminderNeedsDueTime = errors.New("todoist: relative reminders need a task that is due at a time")
+
+// Reminder is a reminder of a task. Which fields are set depends on the type: MinuteOffset for relative reminders, Due for absolute
+// ones, and Location for location ones.
+type Reminder struct {
+	ID           int64             `json:"id" db:"id"`
+	TaskID       int64             `json:"task_id" db:"task_id"`
+	NotifyUID    int64             `json:"notify_uid" db:"notify_uid"` // the user to remind
+	Type         ReminderType      `json:"type" db:"type"`
+	MinuteOffset int64             `json:"minute_offset" db:"minute_offset"`
+	Due          TaskDueInfo       `json:"due" db:"due"`
+	Location     *ReminderLocation `json:"location,omitempty" db:"location"`
+}
+
+// ReminderLocation is the place of a location reminder
+type ReminderLocation struct {
+	Name      string          `json:"name" db:"name"`
+	Latitude  float64         `json:"latitude" db:"latitude"`
+	Longitude float64         `json:"longitude" db:"longitude"`
+	Radius    int64           `json:"radius" db:"radius"` // in meters
+	Trigger   LocationTrigger `json:"trigger" db:"trigger"`
+}
+
+// ReminderParams are the fields set during creating or updating a reminder. Set only the fields of its type: MinuteOffset for a relative
+// reminder; a due string or datetime for an absolute one; and the name, coordinates, radius, and trigger for a location one. There is no
+// due date, unlike TaskParams, because a reminder fires at a moment, which a date alone does not give.
+type ReminderParams struct {
+	Type         ReminderType    `json:"type,omitempty" db:"type"`
+	NotifyUID    *int64          `json:"notify_uid,omitempty" db:"notify_uid"`
+	MinuteOffset *int64          `json:"minute_offset,omitempty" db:"minute_offset"`
+	DueString    *string         `json:"due_string,omitempty" db:"due_string"`
+	DueDatetime  *string         `json:"due_datetime,omitempty" db:"due_datetime"`
+	DueLang      *string         `json:"due_lang,omitempty" db:"due_lang"`
+	Name         *string         `json:"name,omitempty" db:"name"`
+	Latitude     *float64        `json:"latitude,omitempty" db:"latitude"`
+	Longitude    *float64        `json:"longitude,omitempty" db:"longitude"`
+	Radius       *int64          `json:"radius,omitempty" db:"radius"`
+	Trigger      LocationTrigger `json:"trigger,omitempty" db:"trigger"`
+}
+
+// Validate checks the params of a new reminder, which needs a type and the fields that type requires
+func (p *ReminderParams) Validate() error {
+	if p == nil {
+		return errors.New("you must pass in a valid input")
+	}
+	if p.Type == "" {
+		return errors.New("type is required")
+	}
+	if err := p.validate(p.Type); err != nil {
+		return err
+	}
+	switch p.Type {
+	case ReminderTypeRelative:
+		if p.MinuteOffset == nil {
+			return errors.New("a relative reminder needs a minute offset")
+		}
+	case ReminderTypeAbsolute:
+		if p.DueString == nil && p.DueDatetime == nil {
+			return errors.New("an absolute reminder needs a due string or datetime")
+		}
+	case ReminderTypeLocation:
+		if p.Name == nil || p.Latitude == nil || p.Longitude == nil || p.Trigger == "" {
+			return errors.New("a location reminder needs a name, coordinates, and a trigger")
+		}
+	}
+	return nil
+}
+
+// ValidateFor checks the params of a new reminder on the task, including that the task is due at a time if the reminder is relative
+func (p *ReminderParams) ValidateFor(task *Task) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	return checkReminderTask(p.Type, task)
+}
+
+// validate checks that the params only set fields of the reminder type, and that those are in range
+func (p *ReminderParams) validate(reminderType ReminderType) error {
+	relative := p.MinuteOffset != nil
+	absolute := p.DueString != nil || p.DueDatetime != nil || p.DueLang != nil
+	location := p.Name != nil || p.Latitude != nil || p.Longitude != nil || p.Radius != nil || p.Trigger != ""
+	var foreign bool
+	switch reminderType {
+	case ReminderTypeRelative:
+		foreign = absolute || location
+	case ReminderTypeAbsolute:
+		foreign = relative || location
+	case ReminderTypeLocation:
+		foreign = relative || absolute
+	default:
+		return fmt.Errorf("unknown reminder type %q", reminderType)
+	}
+	if foreign {
+		return fmt.Errorf("the params set fields that a %s reminder does not have", reminderType)
+	}
+	if p.MinuteOffset != nil && *p.MinuteOffset < 0 {
+		return errors.New("the minute offset cannot be negative")
+	}
+	if p.DueDatetime != nil && !strings.Contains(*p.DueDatetime, "T") {
+		return errors.New("the due datetime needs a time, not only a date")
+	}
+	if p.Latitude != nil && (*p.Latitude < -90 || *p.Latitude > 90) {
+		return errors.New("the latitude must be between -90 and 90")
+	}
+	if p.Longitude != nil && (*p.Longitude < -180 || *p.Longitude > 180) {
+		return errors.New("the longitude must be between -180 and 180")
+	}
+	if p.Radius != nil && *p.Radius <= 0 {
+		return errors.New("the radius must be positive")
+	}
+	if p.Trigger != "" && p.Trigger != LocationTriggerOnEnter && p.Trigger != LocationTriggerOnLeave {
+		return fmt.Errorf("unknown location trigger %q", p.Trigger)
+	}
+	return nil
+}
+
+// apply sets the params on a reminder the way the add and update commands do, so the result can be returned without syncing it again.
+// A due given only as a string is kept as the string; the date Todoist resolves it to is not known until the next sync.
+func (p *ReminderParams) apply(reminder *Reminder) {
+	if p.Type != "" && p.Type != reminder.Type {
+		reminder.Type = p.Type
+		reminder.MinuteOffset = 0
+		reminder.Due = TaskDueInfo{}
+		reminder.Location = nil
+	}
+	if p.NotifyUID != nil {
+		reminder.NotifyUID = *p.NotifyUID
+	}
+	if p.MinuteOffset != nil {
+		reminder.MinuteOffset = *p.MinuteOffset
+	}
+	if p.DueString != nil || p.DueDatetime != nil {
+		reminder.Due = (&syncDue{Date: StringValue(p.DueDatetime), String: StringValue(p.DueString)}).toTaskDueInfo()
+	}
+	if reminder.Type != ReminderTypeLocation {
+		return
+	}
+	if reminder.Location == nil {
+		reminder.Location = &ReminderLocation{}
+	}
+	if p.Name != nil {
+		reminder.Location.Name = *p.Name
+	}
+	if p.Latitude != nil {
+		reminder.Location.Latitude = *p.Latitude
+	}
+	if p.Longitude != nil {
+		reminder.Location.Longitude = *p.Longitude
+	}
+	if p.Radius != nil {
+		reminder.Location.Radius = *p.Radius
+	}
+	if p.Trigger != "" {
+		reminder.Location.Trigger = p.Trigger
+	}
+}
+
+// checkReminderTask checks that a task can take a reminder of the type
+func checkReminderTask(reminderType ReminderType, task *Task) error {
+	if task == nil {
+		return errors.New("task is required")
+	}
+	if reminderType == ReminderTypeRelative && task.Due.Datetime == "" {
+		return ErrReminderNeedsDueTime
+	}
+	return nil
+}
+
+// GetAllReminders gets every reminder of the user. https://developer.todoist.com/sync/v8/#reminders
+func GetAllReminders(token string) ([]Reminder, error) {
+	return clientForToken(token).GetAllReminders()
+}
+
+// GetAllRemindersWithContext is GetAllReminders with a context that can cancel the call or set its deadline
+func GetAllRemindersWithContext(ctx context.Context, token string) ([]Reminder, error) {
+	return clientForToken(token).GetAllRemindersWithContext(ctx)
+}
+
+// GetTaskReminders gets the reminders of a task. https://developer.todoist.com/sync/v8/#reminders
+func GetTaskReminders(token string, taskID int64) ([]Reminder, error) {
+	return clientForToken(token).GetTaskReminders(taskID)
+}
+
+// GetTaskRemindersWithContext is GetTaskReminders with a context that can cancel the call or set its deadline
+func GetTaskRemindersWithContext(ctx context.Context, token string, taskID int64) ([]Reminder, error) {
+	return clientForToken(token).GetTaskRemindersWithContext(ctx, taskID)
+}
+
+// CreateReminder adds a reminder to a task. A relative reminder is refused with ErrReminderNeedsDueTime unless the task is due at a time.
+// The reminder returned is built from the params, so a due given only as a string has no date yet. https://developer.todoist.com/sync/v8/#add-a-reminder
+func CreateReminder(token string, taskID int64, params *ReminderParams) (*Reminder, error) {
+	return clientForToken(token).CreateReminder(taskID, params)
+}
+
+// CreateReminderWithContext is CreateReminder with a context that can cancel the call or set its deadline
+func CreateReminderWithContext(ctx context.Context, token string, taskID int64, params *ReminderParams) (*Reminder, error) {
+	return clientForToken(token).CreateReminderWithContext(ctx, taskID, params)
+}
+
+// UpdateReminder updates a reminder. Changing the type requires setting the fields of the new type. https://developer.todoist.com/sync/v8/#update-a-reminder
+func UpdateReminder(token string, reminderID int64, params *ReminderParams) (*Reminder, error) {
+	return clientForToken(token).UpdateReminder(reminderID, params)
+}
+
+// UpdateReminderWithContext is UpdateReminder with a context that can cancel the call or set its deadline
+func UpdateReminderWithContext(ctx context.Context, token string, reminderID int64, params *ReminderParams) (*Reminder, error) {
+	return clientForToken(token).UpdateReminderWithContext(ctx, reminderID, params)
+}
+
+// DeleteReminder deletes a reminder. https://developer.todoist.com/sync/v8/#delete-a-reminder
+func DeleteReminder(token string, reminderID int64) error {
+	return clientForToken(token).DeleteReminder(reminderID)
+}
+
+// DeleteReminderWithContext is DeleteReminder with a context that can cancel the call or set its deadline
+func DeleteReminderWithContext(ctx context.Context, token string, reminderID int64) error {
+	return clientForToken(token).DeleteReminderWithContext(ctx, reminderID)
+}
+
+// GetAllReminders gets every reminder of the user. https://developer.todoist.com/sync/v8/#reminders
+func (c *Client) GetAllReminders() ([]Reminder, error) {
+	return c.GetAllRemindersWithContext(context.Background())
+}
+
+// GetAllRemindersWithContext is GetAllReminders with a context that can cancel the call or set its deadline
+func (c *Client) GetAllRemindersWithContext(ctx context.Context) ([]Reminder, error) {
+	reminders := []Reminder{}
+	result, err := c.SyncWithContext(ctx, fullSyncToken, SyncResourceReminders)
+	if err != nil {
+		return reminders, err
+	}
+	for i := range result.Reminders {
+		if !result.Reminders[i].IsDeleted {
+			reminders = append(reminders, result.Reminders[i].Reminder)
+		}
+	}
+	return reminders, nil
+}
+
+// GetTaskReminders gets the reminders of a task. The Sync API cannot ask for the reminders of one task, so this reads all of them.
+// https://developer.todoist.com/sync/v8/#reminders
+func (c *Client) GetTaskReminders(taskID int64) ([]Reminder, error) {
+	return c.GetTaskRemindersWithContext(context.Background(), taskID)
+}
+
+// GetTaskRemindersWithContext is GetTaskReminders with a context that can cancel the call or set its deadline
+func (c *Client) GetTaskRemindersWithContext(ctx context.Context, taskID int64) ([]Reminder, error) {
+	found := []Reminder{}
+	if taskID == 0 {
+		return found, errors.New("task id is required")
+	}
+	reminders, err := c.GetAllRemindersWithContext(ctx)
+	if err != nil {
+		return found, err
+	}
+	for _, reminder := range reminders {
+		if reminder.TaskID == taskID {
+			found = append(found, reminder)
+		}
+	}
+	return found, nil
+}
+
+// CreateReminder adds a reminder to a task. A relative reminder is refused with ErrReminderNeedsDueTime unless the task is due at a time.
+// The reminder returned is built from the params, so a due given only as a string has no date yet. https://developer.todoist.com/sync/v8/#add-a-reminder
+func (c *Client) CreateReminder(taskID int64, params *ReminderParams) (*Reminder, error) {
+	return c.CreateReminderWithContext(context.Background(), taskID, params)
+}
+
+// CreateReminderWithContext is CreateReminder with a context that can cancel the call or set its deadline
+func (c *Client) CreateReminderWithContext(ctx context.Context, taskID int64, params *ReminderParams) (*Reminder, error) {
+	if taskID == 0 {
+		return nil, errors.New("task id is required")
+	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	if params.Type == ReminderTypeRelative {
+		if err := c.checkRelativeReminderTask(ctx, taskID); err != nil {
+			return nil, err
+		}
+	}
+	batch := NewCommandBatch()
+	cmd := batch.CreateReminder(IDRef(taskID), params)
+	result, err := c.executeCommand(ctx, batch, cmd)
+	if err != nil {
+		return nil, err
+	}
+	reminderID, found := result.ID(cmd.Ref())
+	if !found {
+		return nil, errors.New("the server did not return the id of the reminder")
+	}
+	reminder := &Reminder{ID: reminderID, TaskID: taskID}
+	params.apply(reminder)
+	return reminder, nil
+}
+
+// UpdateReminder updates a reminder. Changing the type requires setting the fields of the new type. https://developer.todoist.com/sync/v8/#update-a-reminder
+func (c *Client) UpdateReminder(reminderID int64, params *ReminderParams) (*Reminder, error) {
+	return c.UpdateReminderWithContext(context.Background(), reminderID, params)
+}
+
+// UpdateReminderWithContext is UpdateReminder with a context that can cancel the call or set its deadline
+func (c *Client) UpdateReminderWithContext(ctx context.Context, reminderID int64, params *ReminderParams) (*Reminder, error) {
+	if reminderID == 0 {
+		return nil, errors.New("reminder id is required")
+	}
+	if params == nil {
+		return nil, errors.New("you must pass in a valid input")
+	}
+	existing, err := c.getReminder(ctx, reminderID)
+	if err != nil {
+		return nil, err
+	}
+	if params.Type != "" && params.Type != existing.Type {
+		if err = params.Validate(); err != nil {
+			return nil, err
+		}
+	} else if err = params.validate(existing.Type); err != nil {
+		return nil, err
+	}
+	if params.Type == ReminderTypeRelative && existing.Type != ReminderTypeRelative {
+		if err = c.checkRelativeReminderTask(ctx, existing.TaskID); err != nil {
+			return nil, err
+		}
+	}
+	batch := NewCommandBatch()
+	if _, err = c.executeCommand(ctx, batch, batch.UpdateReminder(IDRef(reminderID), params)); err != nil {
+		return nil, err
+	}
+	params.apply(existing)
+	return existing, nil
+}
+
+// DeleteReminder deletes a reminder. https://developer.todoist.com/sync/v8/#delete-a-reminder
+func (c *Client) DeleteReminder(reminderID int64) error {
+	return c.DeleteReminderWithContext(context.Background(), reminderID)
+}
+
+// DeleteReminderWithContext is DeleteReminder with a context that can cancel the call or set its deadline
+func (c *Client) DeleteReminderWithContext(ctx context.Context, reminderID int64) error {
+	if reminderID == 0 {
+		return errors.New("reminder id is required")
+	}
+	batch := NewCommandBatch()
+	_, err := c.executeCommand(ctx, batch, batch.DeleteReminder(IDRef(reminderID)))
+	return err
+}
+
+// checkRelativeReminderTask gets the task to check that it is due at a time
+func (c *Client) checkRelativeReminderTask(ctx context.Context, taskID int64) error {
+	task, err := c.GetActiveTaskWithContext(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	return checkReminderTask(ReminderTypeRelative, task)
+}
+
+// getReminder finds a reminder among the user's reminders
+func (c *Client) getReminder(ctx context.Context, reminderID int64) (*Reminder, error) {
+	reminders, err := c.GetAllRemindersWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range reminders {
+		if reminders[i].ID == reminderID {
+			return &reminders[i], nil
+		}
+	}
+	return nil, fmt.Errorf("reminder %d: %w", reminderID, ErrNotFound)
+}
@@ -0,0 +1,175 @@
+package todoist
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReminderParamsValidate(t *testing.T) {
+	timed := &Task{ID: 1, Due: TaskDueInfo{Date: "2021-06-01", Datetime: "2021-06-01T17:00:00Z"}}
+	allDay := &Task{ID: 2, Due: TaskDueInfo{Date: "2021-06-01"}}
+
+	relative := &ReminderParams{Type: ReminderTypeRelative, MinuteOffset: Int64(30)}
+	assert.Nil(t, relative.ValidateFor(timed))
+	assert.Equal(t, ErrReminderNeedsDueTime, relative.ValidateFor(allDay))
+	assert.Equal(t, ErrReminderNeedsDueTime, relative.ValidateFor(&Task{ID: 3}))
+
+	absolute := &ReminderParams{Type: ReminderTypeAbsolute, DueString: String("tomorrow 9am")}
+	assert.Nil(t, absolute.ValidateFor(allDay))
+	absolute = &ReminderParams{Type: ReminderTypeAbsolute, DueDatetime: String("2021-06-01T09:00:00Z")}
+	assert.Nil(t, absolute.Validate())
+
+	// the params encode like the other params types, leaving out what is not set
+	encoded, err := json.Marshal(absolute)
+	require.Nil(t, err)
+	assert.JSONEq(t, `{"type": "absolute", "due_datetime": "2021-06-01T09:00:00Z"}`, string(encoded))
+
+	location := &ReminderParams{
+		Type:      ReminderTypeLocation,
+		Name:      String("Office"),
+		Latitude:  Float64(41.148581),
+		Longitude: Float64(-8.6105),
+		Radius:    Int64(100),
+		Trigger:   LocationTriggerOnLeave,
+	}
+	assert.Nil(t, location.Validate())
+
+	for _, params := range []*ReminderParams{
+		nil,
+		{},
+		{Type: "sometime"},
+		{Type: ReminderTypeRelative},
+		{Type: ReminderTypeRelative, MinuteOffset: Int64(-5)},
+		{Type: ReminderTypeRelative, MinuteOffset: Int64(30), DueString: String("tomorrow")},
+		{Type: ReminderTypeAbsolute},
+		{Type: ReminderTypeAbsolute, DueString: String("tomorrow"), MinuteOffset: Int64(30)},
+		{Type: ReminderTypeAbsolute, DueDatetime: String("2021-06-01")},
+		{Type: ReminderTypeLocation, Name: String("Office")},
+		{Type: ReminderTypeLocation, Name: String("Office"), Latitude: Float64(91), Longitude: Float64(0), Trigger: LocationTriggerOnEnter},
+		{Type: ReminderTypeLocation, Name: String("Office"), Latitude: Float64(0), Longitude: Float64(0), Trigger: "on_arrival"},
+		{Type: ReminderTypeLocation, Name: String("Office"), Latitude: Float64(0), Longitude: Float64(0), Radius: Int64(0), Trigger: LocationTriggerOnEnter},
+	} {
+		assert.NotNil(t, params.Validate(), "%+v", params)
+	}
+}
+
+func TestReminderCommands(t *testing.T) {
+	batch := NewCommandBatch()
+	task := batch.CreateTask(&TaskParams{Content: String("Escalate"), DueString: String("today 5pm")}, Placement{})
+	created := batch.CreateReminder(task.Ref(), &ReminderParams{
+		Type:      ReminderTypeLocation,
+		Name:      String("Office"),
+		Latitude:  Float64(41.148581),
+		Longitude: Float64(-8.6105),
+		Radius:    Int64(100),
+		Trigger:   LocationTriggerOnEnter,
+	})
+	updated := batch.UpdateReminder(IDRef(7), &ReminderParams{DueDatetime: String("2021-06-01T09:00:00Z")})
+	deleted := batch.DeleteReminder(created.Ref())
+
+	assert.Equal(t, "reminder_add", created.Type)
+	assert.NotEmpty(t, created.TempID)
+	assert.Equal(t, map[string]interface{}{
+		"item_id":     task.Ref(),
+		"type":        ReminderTypeLocation,
+		"name":        "Office",
+		"loc_lat":     "41.148581",
+		"loc_long":    "-8.6105",
+		"radius":      int64(100),
+		"loc_trigger": LocationTriggerOnEnter,
+	}, created.Args)
+	assert.Equal(t, map[string]interface{}{"id": IDRef(7), "due": map[string]interface{}{"date": "2021-06-01T09:00:00Z"}}, updated.Args)
+	assert.Equal(t, "reminder_delete", deleted.Type)
+	assert.Equal(t, created.Ref(), deleted.Args["id"])
+}
+
+func TestReminderClient(t *testing.T) {
+	fake, client := newFakeTodoistClient(t)
+	fake.seed("tasks",
+		map[string]interface{}{"id": 1, "content": "Timed", "due": map[string]interface{}{"date": "2021-06-01", "datetime": "2021-06-01T17:00:00Z"}},
+		map[string]interface{}{"id": 2, "content": "All day", "due": map[string]interface{}{"date": "2021-06-01"}},
+	)
+	fake.seed("reminders",
+		map[string]interface{}{"id": 7, "item_id": 1, "notify_uid": 9, "type": "absolute", "due": map[string]interface{}{"date": "2021-06-01T09:00:00Z", "string": "jun 1 9am"}},
+		map[string]interface{}{"id": 8, "item_id": 2, "notify_uid": 9, "type": "location", "name": "Office", "loc_lat": "41.148581", "loc_long": "-8.6105", "loc_trigger": "on_leave", "radius": 100},
+		map[string]interface{}{"id": 9, "item_id": 1, "type": "relative", "minute_offset": 10, "is_deleted": 1},
+	)
+
+	found, err := client.GetTaskReminders(1)
+	require.Nil(t, err)
+	assert.Equal(t, []Reminder{{
+		ID:        7,
+		TaskID:    1,
+		NotifyUID: 9,
+		Type:      ReminderTypeAbsolute,
+		Due:       TaskDueInfo{Date: "2021-06-01", Datetime: "2021-06-01T09:00:00Z", String: "jun 1 9am"},
+	}}, found)
+	found, err = client.GetTaskReminders(2)
+	require.Nil(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, &ReminderLocation{Name: "Office", Latitude: 41.148581, Longitude: -8.6105, Radius: 100, Trigger: LocationTriggerOnLeave}, found[0].Location)
+
+	_, err = client.CreateReminder(2, &ReminderParams{Type: ReminderTypeRelative, MinuteOffset: Int64(30)})
+	assert.Equal(t, ErrReminderNeedsDueTime, err)
+	assert.Empty(t, fake.commands())
+
+	// the created and updated reminders are built from the params, not synced again
+	fake.syncReads = nil
+	reminder, err := client.CreateReminder(1, &ReminderParams{Type: ReminderTypeRelative, MinuteOffset: Int64(30)})
+	require.Nil(t, err)
+	assert.Equal(t, &Reminder{ID: fake.nextID, TaskID: 1, Type: ReminderTypeRelative, MinuteOffset: 30}, reminder)
+	assert.Empty(t, fake.syncReads)
+	found, err = client.GetTaskReminders(1)
+	require.Nil(t, err)
+	require.Len(t, found, 2)
+	assert.Equal(t, *reminder, found[1])
+
+	_, err = client.UpdateReminder(8, &ReminderParams{Type: ReminderTypeRelative, MinuteOffset: Int64(15)})
+	assert.Equal(t, ErrReminderNeedsDueTime, err)
+	_, err = client.UpdateReminder(7, &ReminderParams{MinuteOffset: Int64(15)})
+	assert.NotNil(t, err)
+	assert.Len(t, fake.commands(), 1)
+
+	fake.syncReads = nil
+	reminder, err = client.UpdateReminder(7, &ReminderParams{DueString: String("jun 2 9am")})
+	require.Nil(t, err)
+	assert.Equal(t, &Reminder{ID: 7, TaskID: 1, NotifyUID: 9, Type: ReminderTypeAbsolute, Due: TaskDueInfo{String: "jun 2 9am"}}, reminder)
+	assert.Len(t, fake.syncReads, 1)
+
+	_, err = client.UpdateReminder(9, &ReminderParams{MinuteOffset: Int64(15)})
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	require.Nil(t, client.DeleteReminder(8))
+	found, err = client.GetTaskReminders(2)
+	require.Nil(t, err)
+	assert.Empty(t, found)
+	assert.Equal(t, []string{"reminder_add", "reminder_update", "reminder_delete"}, fake.commandTypes())
+	assert.Equal(t, map[string]interface{}{"item_id": float64(1), "type": "relative", "minute_offset": float64(30)}, fake.commands()[0]["args"])
+
+	assert.NotNil(t, client.DeleteReminder(0))
+	_, err = client.GetTaskReminders(0)
+	assert.NotNil(t, err)
+}
+
+func TestReminderParamsApply(t *testing.T) {
+	reminder := &Reminder{ID: 7, TaskID: 1, Type: ReminderTypeRelative, MinuteOffset: 30}
+	(&ReminderParams{DueDatetime: String("2021-06-02T09:00:00Z"), DueString: String("jun 2 9am")}).apply(reminder)
+	assert.Equal(t, TaskDueInfo{Date: "2021-06-02", Datetime: "2021-06-02T09:00:00Z", String: "jun 2 9am"}, reminder.Due)
+	assert.Equal(t, int64(30), reminder.MinuteOffset)
+
+	// a new type drops the fields of the old one
+	(&ReminderParams{Type: ReminderTypeLocation, Name: String("Office"), Radius: Int64(100), Trigger: LocationTriggerOnEnter}).apply(reminder)
+	assert.Equal(t, &Reminder{
+		ID:       7,
+		TaskID:   1,
+		Type:     ReminderTypeLocation,
+		Location: &ReminderLocation{Name: "Office", Radius: 100, Trigger: LocationTriggerOnEnter},
+	}, reminder)
+	(&ReminderParams{Latitude: Float64(41.148581), NotifyUID: Int64(9)}).apply(reminder)
+	assert.Equal(t, 41.148581, reminder.Location.Latitude)
+	assert.Equal(t, "Office", reminder.Location.Name)
+	assert.Equal(t, int64(9), reminder.NotifyUID)
+}
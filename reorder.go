@@ -0,0 +1,168 @@
+package todoist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// checkOrderIDs checks that there is at least one id to order, and that none is zero or repeated
+func checkOrderIDs(kind string, ids []int64) error {
+	if len(ids) == 0 {
+		return fmt.Errorf("at least one %s id is required", kind)
+	}
+	seen := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		if id == 0 {
+			return fmt.Errorf("%s ids cannot be zero", kind)
+		}
+		if seen[id] {
+			return fmt.Errorf("%s id %d is repeated", kind, id)
+		}
+		seen[id] = true
+	}
+	return nil
+}
+
+// ReorderTasks puts sibling tasks, which share a project, section, and parent, in the order of the ids.
+// https://developer.todoist.com/sync/v8/#reorder-items
+func ReorderTasks(token string, taskIDs []int64) error {
+	return clientForToken(token).ReorderTasks(taskIDs)
+}
+
+// ReorderTasksWithContext is ReorderTasks with a context that can cancel the call or set its deadline
+func ReorderTasksWithContext(ctx context.Context, token string, taskIDs []int64) error {
+	return clientForToken(token).ReorderTasksWithContext(ctx, taskIDs)
+}
+
+// ReorderTodayTasks puts tasks in the order of the ids in the Today view. https://developer.todoist.com/sync/v8/#update-day-orders
+func ReorderTodayTasks(token string, taskIDs []int64) error {
+	return clientForToken(token).ReorderTodayTasks(taskIDs)
+}
+
+// ReorderTodayTasksWithContext is ReorderTodayTasks with a context that can cancel the call or set its deadline
+func ReorderTodayTasksWithContext(ctx context.Context, token string, taskIDs []int64) error {
+	return clientForToken(token).ReorderTodayTasksWithContext(ctx, taskIDs)
+}
+
+// ReorderSections puts the sections of a project in the order of the ids. https://developer.todoist.com/sync/v8/#reorder-sections
+func ReorderSections(token string, sectionIDs []int64) error {
+	return clientForToken(token).ReorderSections(sectionIDs)
+}
+
+// ReorderSectionsWithContext is ReorderSections with a context that can cancel the call or set its deadline
+func ReorderSectionsWithContext(ctx context.Context, token string, sectionIDs []int64) error {
+	return clientForToken(token).ReorderSectionsWithContext(ctx, sectionIDs)
+}
+
+// ReorderProjects makes the projects the children of the parent, or top level projects if the parent id is zero, in the order of the
+// ids. Every project is moved along with the reorder, all in one request, so at most 99 projects can be reordered at once.
+// https://developer.todoist.com/sync/v8/#reorder-projects
+func ReorderProjects(token string, parentID int64, projectIDs []int64) error {
+	return clientForToken(token).ReorderProjects(parentID, projectIDs)
+}
+
+// ReorderProjectsWithContext is ReorderProjects with a context that can cancel the call or set its deadline
+func ReorderProjectsWithContext(ctx context.Context, token string, parentID int64, projectIDs []int64) error {
+	return clientForToken(token).ReorderProjectsWithContext(ctx, parentID, projectIDs)
+}
+
+// ReorderLabels puts the labels in the order of the ids. https://developer.todoist.com/sync/v8/#update-multiple-orders
+func ReorderLabels(token string, labelIDs []int64) error {
+	return clientForToken(token).ReorderLabels(labelIDs)
+}
+
+// ReorderLabelsWithContext is ReorderLabels with a context that can cancel the call or set its deadline
+func ReorderLabelsWithContext(ctx context.Context, token string, labelIDs []int64) error {
+	return clientForToken(token).ReorderLabelsWithContext(ctx, labelIDs)
+}
+
+// ReorderTasks puts sibling tasks, which share a project, section, and parent, in the order of the ids.
+// https://developer.todoist.com/sync/v8/#reorder-items
+func (c *Client) ReorderTasks(taskIDs []int64) error {
+	return c.ReorderTasksWithContext(context.Background(), taskIDs)
+}
+
+// ReorderTasksWithContext is ReorderTasks with a context that can cancel the call or set its deadline
+func (c *Client) ReorderTasksWithContext(ctx context.Context, taskIDs []int64) error {
+	if err := checkOrderIDs("task", taskIDs); err != nil {
+		return err
+	}
+	batch := NewCommandBatch()
+	_, err := c.executeCommand(ctx, batch, batch.ReorderTasks(taskIDs))
+	return err
+}
+
+// ReorderTodayTasks puts tasks in the order of the ids in the Today view. https://developer.todoist.com/sync/v8/#update-day-orders
+func (c *Client) ReorderTodayTasks(taskIDs []int64) error {
+	return c.ReorderTodayTasksWithContext(context.Background(), taskIDs)
+}
+
+// ReorderTodayTasksWithContext is ReorderTodayTasks with a context that can cancel the call or set its deadline
+func (c *Client) ReorderTodayTasksWithContext(ctx context.Context, taskIDs []int64) error {
+	if err := checkOrderIDs("task", taskIDs); err != nil {
+		return err
+	}
+	batch := NewCommandBatch()
+	_, err := c.executeCommand(ctx, batch, batch.ReorderTodayTasks(taskIDs))
+	return err
+}
+
+// ReorderSections puts the sections of a project in the order of the ids. https://developer.todoist.com/sync/v8/#reorder-sections
+func (c *Client) ReorderSections(sectionIDs []int64) error {
+	return c.ReorderSectionsWithContext(context.Background(), sectionIDs)
+}
+
+// ReorderSectionsWithContext is ReorderSections with a context that can cancel the call or set its deadline
+func (c *Client) ReorderSectionsWithContext(ctx context.Context, sectionIDs []int64) error {
+	if err := checkOrderIDs("section", sectionIDs); err != nil {
+		return err
+	}
+	batch := NewCommandBatch()
+	_, err := c.executeCommand(ctx, batch, batch.ReorderSections(sectionIDs))
+	return err
+}
+
+// ReorderProjects makes the projects the children of the parent, or top level projects if the parent id is zero, in the order of the
+// ids. Every project is moved along with the reorder, all in one request, so at most 99 projects can be reordered at once.
+// https://developer.todoist.com/sync/v8/#reorder-projects
+func (c *Client) ReorderProjects(parentID int64, projectIDs []int64) error {
+	return c.ReorderProjectsWithContext(context.Background(), parentID, projectIDs)
+}
+
+// ReorderProjectsWithContext is ReorderProjects with a context that can cancel the call or set its deadline
+func (c *Client) ReorderProjectsWithContext(ctx context.Context, parentID int64, projectIDs []int64) error {
+	if err := checkOrderIDs("project", projectIDs); err != nil {
+		return err
+	}
+	// a move per project and the reorder have to fit in one request, so the reorder never runs without its moves
+	if len(projectIDs) >= maxCommandsPerRequest {
+		return fmt.Errorf("at most %d projects can be reordered at once", maxCommandsPerRequest-1)
+	}
+	batch := NewCommandBatch()
+	for _, id := range projectIDs {
+		if id == parentID {
+			return errors.New("a project cannot be its own parent")
+		}
+		// moving a project to the parent it already has leaves it where it is, which saves reading the current parents first
+		batch.MoveProject(IDRef(id), IDRef(parentID))
+	}
+	batch.ReorderProjects(projectIDs)
+	_, err := c.ExecuteBatchWithContext(ctx, batch)
+	return err
+}
+
+// ReorderLabels puts the labels in the order of the ids. https://developer.todoist.com/sync/v8/#update-multiple-orders
+func (c *Client) ReorderLabels(labelIDs []int64) error {
+	return c.ReorderLabelsWithContext(context.Background(), labelIDs)
+}
+
+// ReorderLabelsWithContext is ReorderLabels with a context that can cancel the call or set its deadline
+func (c *Client) ReorderLabelsWithContext(ctx context.Context, labelIDs []int64) error {
+	if err := checkOrderIDs("label", labelIDs); err != nil {
+		return err
+	}
+	batch := NewCommandBatch()
+	_, err := c.executeCommand(ctx, batch, batch.ReorderLabels(labelIDs))
+	return err
+}
@@ -0,0 +1,104 @@
+package todoist
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReorderCommands(t *testing.T) {
+	batch := NewCommandBatch()
+	assert.Equal(t, map[string]interface{}{"items": []map[string]interface{}{
+		{"id": int64(3), "child_order": int64(1)},
+		{"id": int64(1), "child_order": int64(2)},
+	}}, batch.ReorderTasks([]int64{3, 1}).Args)
+	assert.Equal(t, map[string]interface{}{"ids_to_orders": map[string]int64{"3": 1, "1": 2}}, batch.ReorderTodayTasks([]int64{3, 1}).Args)
+	assert.Equal(t, map[string]interface{}{"sections": []map[string]interface{}{
+		{"id": int64(20), "section_order": int64(1)},
+	}}, batch.ReorderSections([]int64{20}).Args)
+	assert.Equal(t, map[string]interface{}{"projects": []map[string]interface{}{
+		{"id": int64(2), "child_order": int64(1)},
+	}}, batch.ReorderProjects([]int64{2}).Args)
+	assert.Equal(t, map[string]interface{}{"id_order_mapping": map[string]int64{"8": 1}}, batch.ReorderLabels([]int64{8}).Args)
+	assert.Equal(t, map[string]interface{}{"id": IDRef(2), "parent_id": IDRef(1)}, batch.MoveProject(IDRef(2), IDRef(1)).Args)
+	assert.Equal(t, map[string]interface{}{"id": IDRef(2), "parent_id": nil}, batch.MoveProject(IDRef(2), Ref{}).Args)
+
+	types := []string{}
+	for _, cmd := range batch.Commands() {
+		types = append(types, cmd.Type)
+	}
+	assert.Equal(t, []string{"item_reorder", "item_update_day_orders", "section_reorder", "project_reorder", "label_update_orders", "project_move", "project_move"}, types)
+}
+
+func TestReorderClient(t *testing.T) {
+	fake, client := newFakeTodoistClient(t)
+	fake.seed("projects",
+		map[string]interface{}{"id": 1, "name": "Clients"},
+		map[string]interface{}{"id": 4, "name": "Client A", "parent_id": 1},
+		map[string]interface{}{"id": 5, "name": "Client B"},
+	)
+	fake.commandErrors["section_reorder"] = &CommandError{Code: 20, Message: "Section not found", HTTPCode: http.StatusNotFound}
+
+	require.Nil(t, client.ReorderTasks([]int64{3, 1, 2}))
+	require.Nil(t, client.ReorderTodayTasks([]int64{2, 3}))
+	require.Nil(t, client.ReorderLabels([]int64{8, 9}))
+
+	err := client.ReorderSections([]int64{20, 30})
+	commandErr := &CommandError{}
+	require.ErrorAs(t, err, &commandErr)
+	assert.Equal(t, "Section not found", commandErr.Message)
+
+	require.Len(t, fake.syncRequests, 4)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"id": float64(3), "child_order": float64(1)},
+		map[string]interface{}{"id": float64(1), "child_order": float64(2)},
+		map[string]interface{}{"id": float64(2), "child_order": float64(3)},
+	}, fake.syncRequests[0][0]["args"].(map[string]interface{})["items"])
+	assert.Equal(t, map[string]interface{}{"2": float64(1), "3": float64(2)}, fake.syncRequests[1][0]["args"].(map[string]interface{})["ids_to_orders"])
+
+	for _, ids := range [][]int64{nil, {}, {1, 0}, {1, 2, 1}} {
+		assert.NotNil(t, client.ReorderTasks(ids), "%v", ids)
+	}
+	assert.NotNil(t, client.ReorderProjects(4, []int64{4, 5}))
+	assert.Len(t, fake.syncRequests, 4)
+	assert.Empty(t, fake.syncReads)
+}
+
+func TestReorderProjects(t *testing.T) {
+	fake, client := newFakeTodoistClient(t)
+	fake.seed("projects",
+		map[string]interface{}{"id": 1, "name": "Clients"},
+		map[string]interface{}{"id": 4, "name": "Client A", "parent_id": 1},
+		map[string]interface{}{"id": 5, "name": "Client B"},
+	)
+
+	// every project is moved, in the same request as the reorder and without reading the current parents first
+	require.Nil(t, client.ReorderProjects(1, []int64{5, 4}))
+	assert.Empty(t, fake.syncReads)
+	require.Len(t, fake.syncRequests, 1)
+	projects := fake.syncRequests[0]
+	require.Len(t, projects, 3)
+	assert.Equal(t, "project_move", projects[0]["type"])
+	assert.Equal(t, map[string]interface{}{"id": float64(5), "parent_id": float64(1)}, projects[0]["args"])
+	assert.Equal(t, map[string]interface{}{"id": float64(4), "parent_id": float64(1)}, projects[1]["args"])
+	assert.Equal(t, "project_reorder", projects[2]["type"])
+	assert.Equal(t, float64(1), fake.collections["projects"][5]["parent_id"])
+
+	require.Nil(t, client.ReorderProjects(0, []int64{4}))
+	assert.Equal(t, map[string]interface{}{"id": float64(4), "parent_id": nil}, fake.syncRequests[1][0]["args"])
+
+	// 99 moves and the reorder fill one request, and one more project would not fit
+	ids := []int64{}
+	for id := int64(100); id < 200; id++ {
+		fake.seed("projects", map[string]interface{}{"id": id, "name": fmt.Sprintf("Project %d", id)})
+		ids = append(ids, id)
+	}
+	assert.NotNil(t, client.ReorderProjects(1, ids))
+	assert.Len(t, fake.syncRequests, 2)
+	require.Nil(t, client.ReorderProjects(1, ids[:99]))
+	require.Len(t, fake.syncRequests, 3)
+	assert.Len(t, fake.syncRequests[2], 100)
+}
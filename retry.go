@@ -0,0 +1,119 @@
+package todoist
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how a Client retries calls that fail with a rate limit (429), a transient server error (5xx), or a network error.
+// Waits between attempts grow exponentially with random jitter, and a Retry-After header from Todoist takes precedence over the computed
+// wait. Only calls that are safe to repeat are retried: GET, PUT, and DELETE calls, and any call whose context carries an idempotency
+// key from WithIdempotencyKey.
+type RetryPolicy struct {
+	MaxAttempts     int                // the total number of attempts, including the first; 1 or less disables retries
+	MaxElapsedTime  time.Duration      // stop retrying once the next attempt would start this long after the first; 0 means no limit
+	InitialInterval time.Duration      // the wait before the first retry
+	MaxInterval     time.Duration      // the upper bound of the computed wait; 0 means no bound
+	Multiplier      float64            // how much the wait grows after each retry
+	Jitter          float64            // the fraction, from 0 to 1, by which each wait is randomly shortened or lengthened
+	OnRetry         func(RetryAttempt) // called before waiting for each retry, if set
+}
+
+// RetryAttempt describes a failed attempt that is about to be retried
+type RetryAttempt struct {
+	Endpoint string        // the name of the endpoint being called
+	Attempt  int           // the attempt that failed, starting at 1
+	Wait     time.Duration // how long the Client will wait before the next attempt
+	Err      error         // the error of the failed attempt
+}
+
+// DefaultRetryPolicy is the policy a Client uses unless it is created with WithRetryPolicy
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:     4,
+		MaxElapsedTime:  2 * time.Minute,
+		InitialInterval: 500 * time.Millisecond,
+		MaxInterval:     30 * time.Second,
+		Multiplier:      2,
+		Jitter:          0.5,
+	}
+}
+
+// WithRetryPolicy sets the policy the Client uses to retry failed calls. Pass RetryPolicy{} to disable retries.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retryPolicy = policy
+	}
+}
+
+type idempotencyKeyContextKey struct{}
+
+// WithIdempotencyKey returns a context that sends the key as the X-Request-Id of a call. Todoist uses it to discard repeated requests,
+// which allows the Client to retry calls, such as creates, that would otherwise be unsafe to repeat. Use a unique key per logical call.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
+func idempotencyKeyFromContext(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKeyContextKey{}).(string)
+	return key
+}
+
+// nextWait decides whether to retry after the attempt failed with err, and how long to wait first
+func (p RetryPolicy) nextWait(attempt int, elapsed time.Duration, err error) (time.Duration, bool) {
+	if attempt >= p.MaxAttempts || !isRetryableError(err) {
+		return 0, false
+	}
+
+	wait := time.Duration(float64(p.InitialInterval) * math.Pow(math.Max(p.Multiplier, 1), float64(attempt-1)))
+	if p.MaxInterval > 0 && wait > p.MaxInterval {
+		wait = p.MaxInterval
+	}
+	if p.Jitter > 0 {
+		wait = time.Duration(float64(wait) * (1 + p.Jitter*(2*rand.Float64()-1)))
+	}
+	apiErr := &APIError{}
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		wait = apiErr.RetryAfter
+	}
+
+	if p.MaxElapsedTime > 0 && elapsed+wait > p.MaxElapsedTime {
+		return 0, false
+	}
+	return wait, true
+}
+
+// isRetryableError reports whether the failure is likely to be transient
+func isRetryableError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	apiErr := &APIError{}
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests ||
+			(apiErr.StatusCode >= http.StatusInternalServerError && apiErr.StatusCode != http.StatusNotImplemented)
+	}
+	// anything else failed before a response arrived, such as a dropped connection
+	return true
+}
+
+// parseRetryAfter reads a Retry-After header, which is either a number of seconds or an HTTP date
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if when, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(when); wait > 0 {
+			return wait
+		}
+	}
+	return 0
+}
@@ -0,0 +1,145 @@
+package todoist
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryTransientFailures(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&calls, 1) {
+		case 1:
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.Write([]byte(`[{"id": 42, "content": "platypus"}]`))
+		}
+	}))
+	defer server.Close()
+
+	attempts := []RetryAttempt{}
+	client := NewClient("test", WithBaseURL(server.URL), WithRetryPolicy(RetryPolicy{
+		MaxAttempts:     3,
+		InitialInterval: time.Millisecond,
+		Multiplier:      2,
+		OnRetry: func(attempt RetryAttempt) {
+			attempts = append(attempts, attempt)
+		},
+	}))
+	tasks, err := client.GetActiveTasks()
+	assert.Nil(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, int64(42), tasks[0].ID)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+
+	require.Len(t, attempts, 2)
+	assert.Equal(t, EndpointNameGetAllActiveTasks, attempts[0].Endpoint)
+	assert.Equal(t, 1, attempts[0].Attempt)
+	assert.Equal(t, time.Second, attempts[0].Wait)
+	assert.True(t, errors.Is(attempts[0].Err, ErrRateLimited))
+	assert.Equal(t, 2, attempts[1].Attempt)
+	assert.Equal(t, 2*time.Millisecond, attempts[1].Wait)
+	assert.True(t, errors.Is(attempts[1].Err, ErrServerError))
+}
+
+func TestRetryOnlyIdempotentCalls(t *testing.T) {
+	var calls int32
+	status := int32(http.StatusInternalServerError)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(int(atomic.LoadInt32(&status)))
+	}))
+	defer server.Close()
+	client := NewClient("test", WithBaseURL(server.URL), WithRetryPolicy(RetryPolicy{
+		MaxAttempts:     3,
+		InitialInterval: time.Millisecond,
+	}))
+
+	// a close is a POST, so it is not repeated
+	err := client.CloseTask(42)
+	assert.True(t, errors.Is(err, ErrServerError))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	// unless it carries an idempotency key
+	atomic.StoreInt32(&calls, 0)
+	err = client.CloseTaskWithContext(WithIdempotencyKey(context.Background(), "close-42"), 42)
+	assert.True(t, errors.Is(err, ErrServerError))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	apiErr := &APIError{}
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, "close-42", apiErr.RequestID)
+
+	// errors that will not go away are never retried
+	atomic.StoreInt32(&calls, 0)
+	atomic.StoreInt32(&status, http.StatusNotFound)
+	_, err = client.GetActiveTask(42)
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestRetryLimits(t *testing.T) {
+	policy := RetryPolicy{
+		MaxAttempts:     5,
+		MaxElapsedTime:  time.Second,
+		InitialInterval: 100 * time.Millisecond,
+		MaxInterval:     300 * time.Millisecond,
+		Multiplier:      2,
+	}
+	serverErr := &APIError{StatusCode: http.StatusBadGateway}
+
+	wait, retry := policy.nextWait(1, 0, serverErr)
+	assert.True(t, retry)
+	assert.Equal(t, 100*time.Millisecond, wait)
+	wait, retry = policy.nextWait(3, 0, serverErr)
+	assert.True(t, retry)
+	assert.Equal(t, 300*time.Millisecond, wait)
+	_, retry = policy.nextWait(5, 0, serverErr)
+	assert.False(t, retry)
+	_, retry = policy.nextWait(2, 900*time.Millisecond, serverErr)
+	assert.False(t, retry)
+	_, retry = policy.nextWait(1, 0, context.Canceled)
+	assert.False(t, retry)
+	_, retry = policy.nextWait(1, 0, &APIError{StatusCode: http.StatusNotImplemented})
+	assert.False(t, retry)
+	_, retry = policy.nextWait(1, 0, errors.New("connection reset by peer"))
+	assert.True(t, retry)
+
+	// jitter stays within its bounds
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		wait, _ = policy.nextWait(1, 0, serverErr)
+		assert.True(t, wait >= 50*time.Millisecond && wait <= 150*time.Millisecond)
+	}
+
+	assert.Equal(t, 2*time.Second, parseRetryAfter("2"))
+	assert.Zero(t, parseRetryAfter(""))
+	assert.Zero(t, parseRetryAfter("soon"))
+	assert.True(t, parseRetryAfter(time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)) > 30*time.Second)
+}
+
+func TestRetryWaitHonorsContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+	client := NewClient("test", WithBaseURL(server.URL))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := client.GetAllLabelsWithContext(ctx)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.True(t, time.Since(start) < 10*time.Second)
+}
@@ -1,6 +1,7 @@
 package todoist
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -9,10 +10,11 @@ import (
 
 // Section divides a project into logical sections
 type Section struct {
-	ID        int64  `json:"id" db:"id"`
-	ProjectID int64  `json:"project_id" db:"project_id"`
-	Name      string `json:"name" db:"name"`
-	Order     int64  `json:"order" db:"order"`
+	ID         int64  `json:"id" db:"id"`
+	ProjectID  int64  `json:"project_id" db:"project_id"`
+	Name       string `json:"name" db:"name"`
+	Order      int64  `json:"order" db:"order"`
+	IsArchived bool   `json:"is_archived" db:"is_archived"`
 }
 
 // SectionParams are the fields used when creating or editing sections
@@ -24,12 +26,67 @@ type SectionParams struct {
 
 // GetAllSections returns all of the sections for a user's token. If provided a non-zero project ID, it will get only sections for that project. https://developer.todoist.com/rest/v1/#get-all-sections
 func GetAllSections(token string, projectID int64) ([]Section, error) {
+	return clientForToken(token).GetAllSections(projectID)
+}
+
+// GetAllSectionsWithContext is GetAllSections with a context that can cancel the call or set its deadline
+func GetAllSectionsWithContext(ctx context.Context, token string, projectID int64) ([]Section, error) {
+	return clientForToken(token).GetAllSectionsWithContext(ctx, projectID)
+}
+
+// CreateSection creates a section and requires at least a name and project_id. https://developer.todoist.com/rest/v1/#create-a-new-section
+func CreateSection(token string, input *SectionParams) (*Section, error) {
+	return clientForToken(token).CreateSection(input)
+}
+
+// CreateSectionWithContext is CreateSection with a context that can cancel the call or set its deadline
+func CreateSectionWithContext(ctx context.Context, token string, input *SectionParams) (*Section, error) {
+	return clientForToken(token).CreateSectionWithContext(ctx, input)
+}
+
+// GetSection gets a single section. https://developer.todoist.com/rest/v1/#get-a-single-section
+func GetSection(token string, sectionID int64) (*Section, error) {
+	return clientForToken(token).GetSection(sectionID)
+}
+
+// GetSectionWithContext is GetSection with a context that can cancel the call or set its deadline
+func GetSectionWithContext(ctx context.Context, token string, sectionID int64) (*Section, error) {
+	return clientForToken(token).GetSectionWithContext(ctx, sectionID)
+}
+
+// UpdateSection updates a section. Currently, only the name may change. https://developer.todoist.com/rest/v1/#update-a-section
+func UpdateSection(token string, sectionID int64, input *SectionParams) (*Section, error) {
+	return clientForToken(token).UpdateSection(sectionID, input)
+}
+
+// UpdateSectionWithContext is UpdateSection with a context that can cancel the call or set its deadline
+func UpdateSectionWithContext(ctx context.Context, token string, sectionID int64, input *SectionParams) (*Section, error) {
+	return clientForToken(token).UpdateSectionWithContext(ctx, sectionID, input)
+}
+
+// DeleteSection deletes a section. https://developer.todoist.com/rest/v1/#delete-a-section
+func DeleteSection(token string, sectionID int64) error {
+	return clientForToken(token).DeleteSection(sectionID)
+}
+
+// DeleteSectionWithContext is DeleteSection with a context that can cancel the call or set its deadline
+func DeleteSectionWithContext(ctx context.Context, token string, sectionID int64) error {
+	return clientForToken(token).DeleteSectionWithContext(ctx, sectionID)
+}
+
+// GetAllSections returns all of the sections for a user's token. If provided a non-zero project ID, it will get only sections for that project. https://developer.todoist.com/rest/v1/#get-all-sections
+func (c *Client) GetAllSections(projectID int64) ([]Section, error) {
+	return c.GetAllSectionsWithContext(context.Background(), projectID)
+}
+
+// GetAllSectionsWithContext is GetAllSections with a context that can cancel the call or set its deadline
+func (c *Client) GetAllSectionsWithContext(ctx context.Context, projectID int64) ([]Section, error) {
 	sections := []Section{}
 	data := map[string]string{}
 	if projectID != 0 {
 		data["project_id"] = fmt.Sprintf("%d", projectID)
 	}
-	resp, err := makeCall(token, EndpointNameGetAllSections, map[string]string{}, data)
+	resp, err := c.makeCall(ctx, EndpointNameGetAllSections, map[string]string{}, data)
 	if err != nil {
 		return sections, err
 	}
@@ -38,14 +95,19 @@ func GetAllSections(token string, projectID int64) ([]Section, error) {
 }
 
 // CreateSection creates a section and requires at least a name and project_id. https://developer.todoist.com/rest/v1/#create-a-new-section
-func CreateSection(token string, input *SectionParams) (*Section, error) {
+func (c *Client) CreateSection(input *SectionParams) (*Section, error) {
+	return c.CreateSectionWithContext(context.Background(), input)
+}
+
+// CreateSectionWithContext is CreateSection with a context that can cancel the call or set its deadline
+func (c *Client) CreateSectionWithContext(ctx context.Context, input *SectionParams) (*Section, error) {
 	if input == nil {
 		return nil, errors.New("you must provide a valid input with at least a name field and a project_id field")
 	}
 	if input.Name == "" || input.ProjectID == nil || Int64Value(input.ProjectID) == 0 {
 		return nil, errors.New("name and project_id are required")
 	}
-	resp, err := makeCall(token, EndpointNameCreateSection, map[string]string{}, input)
+	resp, err := c.makeCall(ctx, EndpointNameCreateSection, map[string]string{}, input)
 	if err != nil {
 		return nil, err
 	}
@@ -55,8 +117,13 @@ func CreateSection(token string, input *SectionParams) (*Section, error) {
 }
 
 // GetSection gets a single section. https://developer.todoist.com/rest/v1/#get-a-single-section
-func GetSection(token string, sectionID int64) (*Section, error) {
-	resp, err := makeCall(token, EndpointNameGetSection, map[string]string{
+func (c *Client) GetSection(sectionID int64) (*Section, error) {
+	return c.GetSectionWithContext(context.Background(), sectionID)
+}
+
+// GetSectionWithContext is GetSection with a context that can cancel the call or set its deadline
+func (c *Client) GetSectionWithContext(ctx context.Context, sectionID int64) (*Section, error) {
+	resp, err := c.makeCall(ctx, EndpointNameGetSection, map[string]string{
 		"id": fmt.Sprintf("%d", sectionID),
 	}, nil)
 	if err != nil {
@@ -68,14 +135,19 @@ func GetSection(token string, sectionID int64) (*Section, error) {
 }
 
 // UpdateSection updates a section. Currently, only the name may change. https://developer.todoist.com/rest/v1/#update-a-section
-func UpdateSection(token string, sectionID int64, input *SectionParams) (*Section, error) {
+func (c *Client) UpdateSection(sectionID int64, input *SectionParams) (*Section, error) {
+	return c.UpdateSectionWithContext(context.Background(), sectionID, input)
+}
+
+// UpdateSectionWithContext is UpdateSection with a context that can cancel the call or set its deadline
+func (c *Client) UpdateSectionWithContext(ctx context.Context, sectionID int64, input *SectionParams) (*Section, error) {
 	if input == nil {
 		return nil, errors.New("you must provide a valid input with at least a name field")
 	}
 	if input.Name == "" {
 		return nil, errors.New("name is required")
 	}
-	_, err := makeCall(token, EndpointNameUpdateSection, map[string]string{
+	_, err := c.makeCall(ctx, EndpointNameUpdateSection, map[string]string{
 		"id": fmt.Sprintf("%d", sectionID),
 	}, input)
 	if err != nil {
@@ -83,12 +155,17 @@ func UpdateSection(token string, sectionID int64, input *SectionParams) (*Sectio
 	}
 	// the update itself returns nothing, so we need to
 	// get it again if we want the updated information
-	return GetSection(token, sectionID)
+	return c.GetSectionWithContext(ctx, sectionID)
 }
 
 // DeleteSection deletes a section. https://developer.todoist.com/rest/v1/#delete-a-section
-func DeleteSection(token string, sectionID int64) error {
-	resp, err := makeCall(token, EndpointNameDeleteSection, map[string]string{
+func (c *Client) DeleteSection(sectionID int64) error {
+	return c.DeleteSectionWithContext(context.Background(), sectionID)
+}
+
+// DeleteSectionWithContext is DeleteSection with a context that can cancel the call or set its deadline
+func (c *Client) DeleteSectionWithContext(ctx context.Context, sectionID int64) error {
+	resp, err := c.makeCall(ctx, EndpointNameDeleteSection, map[string]string{
 		"id": fmt.Sprintf("%d", sectionID),
 	}, nil)
 	if err != nil {
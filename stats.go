@@ -0,0 +1,283 @@
+package todoist
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ProductivityStats are the user's karma, completion counts, and goals, as shown in the app's productivity view
+type ProductivityStats struct {
+	Karma           float64
+	KarmaTrend      string // "up" or "down"
+	KarmaLastUpdate float64
+	CompletedCount  int64 // over the lifetime of the account
+	Days            []DailyCompletion
+	Weeks           []WeeklyCompletion
+	KarmaUpdates    []KarmaUpdate
+	Goals           ProductivityGoals
+}
+
+// ProjectCompletion is how many tasks of a project were completed in a day or week
+type ProjectCompletion struct {
+	ProjectID int64
+	Completed int64
+}
+
+// DailyCompletion is how many tasks were completed on a day, in total and by project
+type DailyCompletion struct {
+	Date      time.Time
+	Total     int64
+	ByProject []ProjectCompletion
+}
+
+// WeeklyCompletion is how many tasks were completed in a week, in total and by project
+type WeeklyCompletion struct {
+	From      time.Time
+	To        time.Time
+	Total     int64
+	ByProject []ProjectCompletion
+}
+
+// KarmaUpdate is a change of the user's karma
+type KarmaUpdate struct {
+	Time          time.Time
+	NewKarma      float64
+	PositiveKarma float64
+	NegativeKarma float64
+}
+
+// Streak is a run of consecutive days or weeks in which the user met their goal. Start and End are empty when there is no streak.
+type Streak struct {
+	Count int64  `json:"count"`
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// ProductivityGoals are the user's goals and how well they keep them
+type ProductivityGoals struct {
+	DailyGoal           int64
+	WeeklyGoal          int64
+	IgnoreDays          []int // days of the week that do not count against a streak, from 1 for Monday to 7 for Sunday
+	VacationMode        bool
+	KarmaDisabled       bool
+	CurrentDailyStreak  Streak
+	MaxDailyStreak      Streak
+	CurrentWeeklyStreak Streak
+	MaxWeeklyStreak     Streak
+}
+
+// statsResponse is the shape of a /completed/get_stats response
+type statsResponse struct {
+	Karma           float64 `json:"karma"`
+	KarmaTrend      string  `json:"karma_trend"`
+	KarmaLastUpdate float64 `json:"karma_last_update"`
+	CompletedCount  int64   `json:"completed_count"`
+	DaysItems       []struct {
+		Date           string                  `json:"date"`
+		TotalCompleted int64                   `json:"total_completed"`
+		Items          []statsProjectCompleted `json:"items"`
+	} `json:"days_items"`
+	WeekItems []struct {
+		Date           string                  `json:"date"`
+		TotalCompleted int64                   `json:"total_completed"`
+		Items          []statsProjectCompleted `json:"items"`
+	} `json:"week_items"`
+	KarmaUpdateReasons []struct {
+		Time          string  `json:"time"`
+		NewKarma      float64 `json:"new_karma"`
+		PositiveKarma float64 `json:"positive_karma"`
+		NegativeKarma float64 `json:"negative_karma"`
+	} `json:"karma_update_reasons"`
+	Goals struct {
+		DailyGoal           int64    `json:"daily_goal"`
+		WeeklyGoal          int64    `json:"weekly_goal"`
+		IgnoreDays          []int    `json:"ignore_days"`
+		VacationMode        syncBool `json:"vacation_mode"`
+		KarmaDisabled       syncBool `json:"karma_disabled"`
+		CurrentDailyStreak  Streak   `json:"current_daily_streak"`
+		MaxDailyStreak      Streak   `json:"max_daily_streak"`
+		CurrentWeeklyStreak Streak   `json:"current_weekly_streak"`
+		MaxWeeklyStreak     Streak   `json:"max_weekly_streak"`
+	} `json:"goals"`
+}
+
+type statsProjectCompleted struct {
+	ID        syncInt64 `json:"id"`
+	Completed int64     `json:"completed"`
+}
+
+// karmaTimeLayouts are the formats the karma update times come in
+var karmaTimeLayouts = []string{time.RFC3339, "Mon 02 Jan 2006 15:04:05"}
+
+func (r *statsResponse) toStats() (*ProductivityStats, error) {
+	stats := &ProductivityStats{
+		Karma:           r.Karma,
+		KarmaTrend:      r.KarmaTrend,
+		KarmaLastUpdate: r.KarmaLastUpdate,
+		CompletedCount:  r.CompletedCount,
+		Days:            make([]DailyCompletion, 0, len(r.DaysItems)),
+		Weeks:           make([]WeeklyCompletion, 0, len(r.WeekItems)),
+		KarmaUpdates:    make([]KarmaUpdate, 0, len(r.KarmaUpdateReasons)),
+		Goals: ProductivityGoals{
+			DailyGoal:           r.Goals.DailyGoal,
+			WeeklyGoal:          r.Goals.WeeklyGoal,
+			IgnoreDays:          r.Goals.IgnoreDays,
+			VacationMode:        bool(r.Goals.VacationMode),
+			KarmaDisabled:       bool(r.Goals.KarmaDisabled),
+			CurrentDailyStreak:  r.Goals.CurrentDailyStreak,
+			MaxDailyStreak:      r.Goals.MaxDailyStreak,
+			CurrentWeeklyStreak: r.Goals.CurrentWeeklyStreak,
+			MaxWeeklyStreak:     r.Goals.MaxWeeklyStreak,
+		},
+	}
+	for _, day := range r.DaysItems {
+		date, err := time.Parse("2006-01-02", day.Date)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse the day %q: %w", day.Date, err)
+		}
+		stats.Days = append(stats.Days, DailyCompletion{Date: date, Total: day.TotalCompleted, ByProject: toProjectCompletions(day.Items)})
+	}
+	for _, week := range r.WeekItems {
+		bounds := strings.SplitN(week.Date, "/", 2)
+		if len(bounds) != 2 {
+			return nil, fmt.Errorf("could not parse the week %q", week.Date)
+		}
+		from, err := time.Parse("2006-01-02", bounds[0])
+		if err != nil {
+			return nil, fmt.Errorf("could not parse the week %q: %w", week.Date, err)
+		}
+		to, err := time.Parse("2006-01-02", bounds[1])
+		if err != nil {
+			return nil, fmt.Errorf("could not parse the week %q: %w", week.Date, err)
+		}
+		stats.Weeks = append(stats.Weeks, WeeklyCompletion{From: from, To: to, Total: week.TotalCompleted, ByProject: toProjectCompletions(week.Items)})
+	}
+	for _, update := range r.KarmaUpdateReasons {
+		var updatedAt time.Time
+		var err error
+		for _, layout := range karmaTimeLayouts {
+			if updatedAt, err = time.Parse(layout, update.Time); err == nil {
+				break
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not parse the karma update time %q", update.Time)
+		}
+		stats.KarmaUpdates = append(stats.KarmaUpdates, KarmaUpdate{
+			Time:          updatedAt,
+			NewKarma:      update.NewKarma,
+			PositiveKarma: update.PositiveKarma,
+			NegativeKarma: update.NegativeKarma,
+		})
+	}
+	return stats, nil
+}
+
+func toProjectCompletions(items []statsProjectCompleted) []ProjectCompletion {
+	completions := make([]ProjectCompletion, len(items))
+	for i := range items {
+		completions[i] = ProjectCompletion{ProjectID: int64(items[i].ID), Completed: items[i].Completed}
+	}
+	return completions
+}
+
+// GetProductivityStats gets the user's karma, recent completion counts, and goals. https://developer.todoist.com/sync/v8/#get-productivity-stats
+func GetProductivityStats(token string) (*ProductivityStats, error) {
+	return clientForToken(token).GetProductivityStats()
+}
+
+// GetProductivityStatsWithContext is GetProductivityStats with a context that can cancel the call or set its deadline
+func GetProductivityStatsWithContext(ctx context.Context, token string) (*ProductivityStats, error) {
+	return clientForToken(token).GetProductivityStatsWithContext(ctx)
+}
+
+// GetProductivityStats gets the user's karma, recent completion counts, and goals. https://developer.todoist.com/sync/v8/#get-productivity-stats
+func (c *Client) GetProductivityStats() (*ProductivityStats, error) {
+	return c.GetProductivityStatsWithContext(context.Background())
+}
+
+// GetProductivityStatsWithContext is GetProductivityStats with a context that can cancel the call or set its deadline
+func (c *Client) GetProductivityStatsWithContext(ctx context.Context) (*ProductivityStats, error) {
+	resp, err := c.makeCall(ctx, EndpointNameGetProductivityStats, map[string]string{}, map[string]string{})
+	if err != nil {
+		return nil, err
+	}
+	decoded := &statsResponse{}
+	if err = json.Unmarshal(resp.Body, decoded); err != nil {
+		return nil, err
+	}
+	return decoded.toStats()
+}
+
+// TimeSeriesPoint is a value at a point in time, such as a day's completed tasks
+type TimeSeriesPoint struct {
+	Time  time.Time
+	Value float64
+}
+
+// DailySeries returns the tasks completed each day, oldest first, with the days the server left out filled in as zero. If the project
+// id is not zero, only the tasks of that project are counted.
+func (s *ProductivityStats) DailySeries(projectID int64) []TimeSeriesPoint {
+	counts := map[time.Time]int64{}
+	for _, day := range s.Days {
+		counts[day.Date] = completedIn(day.Total, day.ByProject, projectID)
+	}
+	return fillSeries(counts, func(t time.Time) time.Time { return t.AddDate(0, 0, 1) })
+}
+
+// WeeklySeries returns the tasks completed each week, keyed by the first day of the week, oldest first, with the weeks the server left
+// out filled in as zero. If the project id is not zero, only the tasks of that project are counted.
+func (s *ProductivityStats) WeeklySeries(projectID int64) []TimeSeriesPoint {
+	counts := map[time.Time]int64{}
+	for _, week := range s.Weeks {
+		counts[week.From] = completedIn(week.Total, week.ByProject, projectID)
+	}
+	return fillSeries(counts, func(t time.Time) time.Time { return t.AddDate(0, 0, 7) })
+}
+
+// KarmaSeries returns the karma after each update, oldest first
+func (s *ProductivityStats) KarmaSeries() []TimeSeriesPoint {
+	series := make([]TimeSeriesPoint, len(s.KarmaUpdates))
+	for i, update := range s.KarmaUpdates {
+		series[i] = TimeSeriesPoint{Time: update.Time, Value: update.NewKarma}
+	}
+	sort.Slice(series, func(i, j int) bool { return series[i].Time.Before(series[j].Time) })
+	return series
+}
+
+func completedIn(total int64, byProject []ProjectCompletion, projectID int64) int64 {
+	if projectID == 0 {
+		return total
+	}
+	for _, completion := range byProject {
+		if completion.ProjectID == projectID {
+			return completion.Completed
+		}
+	}
+	return 0
+}
+
+// fillSeries turns the counts into a series from the earliest to the latest time, stepping with next and using zero for missing times
+func fillSeries(counts map[time.Time]int64, next func(time.Time) time.Time) []TimeSeriesPoint {
+	series := []TimeSeriesPoint{}
+	if len(counts) == 0 {
+		return series
+	}
+	var first, last time.Time
+	for t := range counts {
+		if first.IsZero() || t.Before(first) {
+			first = t
+		}
+		if t.After(last) {
+			last = t
+		}
+	}
+	for t := first; !t.After(last); t = next(t) {
+		series = append(series, TimeSeriesPoint{Time: t, Value: float64(counts[t])})
+	}
+	return series
+}
@@ -0,0 +1,82 @@
+package todoist
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testStatsResponse = `{
+	"karma": 1250.5,
+	"karma_trend": "up",
+	"karma_last_update": 5.0,
+	"completed_count": 812,
+	"days_items": [
+		{"date": "2021-06-03", "total_completed": 4, "items": [{"id": "2", "completed": 3}, {"id": 3, "completed": 1}]},
+		{"date": "2021-06-01", "total_completed": 2, "items": [{"id": "2", "completed": 2}]}
+	],
+	"week_items": [
+		{"date": "2021-05-31/2021-06-06", "total_completed": 6, "items": [{"id": "2", "completed": 5}, {"id": "3", "completed": 1}]},
+		{"date": "2021-05-17/2021-05-23", "total_completed": 9, "items": []}
+	],
+	"karma_update_reasons": [
+		{"time": "Thu 03 Jun 2021 10:00:00", "new_karma": 1250.5, "positive_karma": 5.0, "negative_karma": 0.0},
+		{"time": "2021-06-01T10:00:00Z", "new_karma": 1245.5, "positive_karma": 2.0, "negative_karma": 1.0}
+	],
+	"goals": {
+		"daily_goal": 5, "weekly_goal": 25, "ignore_days": [6, 7], "vacation_mode": 0, "karma_disabled": 1,
+		"current_daily_streak": {"count": 2, "start": "2021-06-02", "end": "2021-06-03"},
+		"max_daily_streak": {"count": 12, "start": "2021-01-04", "end": "2021-01-15"},
+		"current_weekly_streak": {"count": 0, "start": "", "end": ""},
+		"max_weekly_streak": {"count": 3, "start": "2021-03-01", "end": "2021-03-21"}
+	}
+}`
+
+func TestGetProductivityStats(t *testing.T) {
+	fake, client := newFakeTodoistClient(t)
+	fake.stats = json.RawMessage(testStatsResponse)
+
+	stats, err := client.GetProductivityStats()
+	require.Nil(t, err)
+	assert.Len(t, fake.calls("completed/get_stats"), 1)
+	assert.Equal(t, 1250.5, stats.Karma)
+	assert.Equal(t, "up", stats.KarmaTrend)
+	assert.Equal(t, int64(812), stats.CompletedCount)
+
+	require.Len(t, stats.Days, 2)
+	assert.Equal(t, time.Date(2021, 6, 3, 0, 0, 0, 0, time.UTC), stats.Days[0].Date)
+	assert.Equal(t, []ProjectCompletion{{ProjectID: 2, Completed: 3}, {ProjectID: 3, Completed: 1}}, stats.Days[0].ByProject)
+	require.Len(t, stats.Weeks, 2)
+	assert.Equal(t, time.Date(2021, 5, 31, 0, 0, 0, 0, time.UTC), stats.Weeks[0].From)
+	assert.Equal(t, time.Date(2021, 6, 6, 0, 0, 0, 0, time.UTC), stats.Weeks[0].To)
+	require.Len(t, stats.KarmaUpdates, 2)
+	assert.Equal(t, time.Date(2021, 6, 3, 10, 0, 0, 0, time.UTC), stats.KarmaUpdates[0].Time)
+
+	assert.Equal(t, int64(5), stats.Goals.DailyGoal)
+	assert.Equal(t, []int{6, 7}, stats.Goals.IgnoreDays)
+	assert.False(t, stats.Goals.VacationMode)
+	assert.True(t, stats.Goals.KarmaDisabled)
+	assert.Equal(t, Streak{Count: 2, Start: "2021-06-02", End: "2021-06-03"}, stats.Goals.CurrentDailyStreak)
+	assert.Equal(t, int64(12), stats.Goals.MaxDailyStreak.Count)
+	assert.Equal(t, int64(3), stats.Goals.MaxWeeklyStreak.Count)
+
+	day := func(d int) time.Time { return time.Date(2021, 6, d, 0, 0, 0, 0, time.UTC) }
+	assert.Equal(t, []TimeSeriesPoint{{day(1), 2}, {day(2), 0}, {day(3), 4}}, stats.DailySeries(0))
+	assert.Equal(t, []TimeSeriesPoint{{day(1), 0}, {day(2), 0}, {day(3), 1}}, stats.DailySeries(3))
+
+	weekly := stats.WeeklySeries(2)
+	require.Len(t, weekly, 3)
+	assert.Equal(t, TimeSeriesPoint{time.Date(2021, 5, 17, 0, 0, 0, 0, time.UTC), 0}, weekly[0])
+	assert.Equal(t, TimeSeriesPoint{time.Date(2021, 5, 24, 0, 0, 0, 0, time.UTC), 0}, weekly[1])
+	assert.Equal(t, TimeSeriesPoint{time.Date(2021, 5, 31, 0, 0, 0, 0, time.UTC), 5}, weekly[2])
+
+	karma := stats.KarmaSeries()
+	require.Len(t, karma, 2)
+	assert.Equal(t, 1245.5, karma[0].Value)
+	assert.Equal(t, 1250.5, karma[1].Value)
+
+	assert.Empty(t, (&ProductivityStats{}).DailySeries(0))
+}
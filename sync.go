@@ -0,0 +1,349 @@
+package todoist
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// SyncResourceType names a kind of object the Sync API can return. https://developer.todoist.com/sync/v8/#read-resources
+type SyncResourceType string
+
+const (
+	SyncResourceAll           SyncResourceType = "all"
+	SyncResourceProjects      SyncResourceType = "projects"
+	SyncResourceItems         SyncResourceType = "items"
+	SyncResourceSections      SyncResourceType = "sections"
+	SyncResourceLabels        SyncResourceType = "labels"
+	SyncResourceNotes         SyncResourceType = "notes"
+	SyncResourceProjectNotes  SyncResourceType = "project_notes"
+	SyncResourceReminders     SyncResourceType = "reminders"
+	SyncResourceFilters       SyncResourceType = "filters"
+	SyncResourceCollaborators SyncResourceType = "collaborators"
+	SyncResourceUser          SyncResourceType = "user"
+)
+
+// fullSyncToken is the sync token that asks for everything instead of the changes since an earlier sync
+const fullSyncToken = "*"
+
+// SyncedTask is a task returned by the Sync API, along with the state the REST API does not expose
+type SyncedTask struct {
+	Task
+	IsDeleted     bool   `json:"is_deleted"`
+	InHistory     bool   `json:"in_history"` // the task is completed and archived
+	DayOrder      int64  `json:"day_order"`
+	Collapsed     bool   `json:"collapsed"`
+	DateAdded     string `json:"date_added"`
+	DateCompleted string `json:"date_completed"`
+}
+
+// SyncedProject is a project returned by the Sync API, along with the state the REST API does not expose
+type SyncedProject struct {
+	Project
+	IsDeleted bool `json:"is_deleted"`
+	Collapsed bool `json:"collapsed"`
+}
+
+// SyncedSection is a section returned by the Sync API, along with the state the REST API does not expose
+type SyncedSection struct {
+	Section
+	IsDeleted    bool   `json:"is_deleted"`
+	Collapsed    bool   `json:"collapsed"`
+	DateArchived string `json:"date_archived"`
+}
+
+// SyncedLabel is a label returned by the Sync API, along with whether it was deleted
+type SyncedLabel struct {
+	Label
+	IsDeleted bool `json:"is_deleted"`
+}
+
+// SyncedComment is a comment on a task or a project returned by the Sync API, along with whether it was deleted
+type SyncedComment struct {
+	Comment
+	IsDeleted bool `json:"is_deleted"`
+}
+
+// SyncedReminder is a reminder returned by the Sync API, along with whether it was deleted
+type SyncedReminder struct {
+	Reminder
+	IsDeleted bool `json:"is_deleted"`
+}
+
+// SyncedFilter is a saved filter returned by the Sync API, along with whether it was deleted
+type SyncedFilter struct {
+	Filter
+	IsDeleted bool `json:"is_deleted"`
+}
+
+// SyncResult is the outcome of a sync. After a full sync it holds every object of the requested resource types; after an incremental
+// sync it holds only the objects that changed since the sync token, with deleted objects flagged instead of left out.
+type SyncResult struct {
+	SyncToken string           `json:"sync_token"`
+	FullSync  bool             `json:"full_sync"`
+	Projects  []SyncedProject  `json:"projects"`
+	Tasks     []SyncedTask     `json:"tasks"`
+	Sections  []SyncedSection  `json:"sections"`
+	Labels    []SyncedLabel    `json:"labels"`
+	Comments  []SyncedComment  `json:"comments"`
+	Reminders []SyncedReminder `json:"reminders"`
+	Filters   []SyncedFilter   `json:"filters"`
+}
+
+// syncResponse is the shape of a /sync response
+type syncResponse struct {
+	SyncToken    string         `json:"sync_token"`
+	FullSync     bool           `json:"full_sync"`
+	Projects     []syncProject  `json:"projects"`
+	Items        []syncItem     `json:"items"`
+	Sections     []syncSection  `json:"sections"`
+	Labels       []syncLabel    `json:"labels"`
+	Notes        []syncNote     `json:"notes"`
+	ProjectNotes []syncNote     `json:"project_notes"`
+	Reminders    []syncReminder `json:"reminders"`
+	Filters      []syncFilter   `json:"filters"`
+}
+
+func (r *syncResponse) toResult() *SyncResult {
+	result := &SyncResult{
+		SyncToken: r.SyncToken,
+		FullSync:  r.FullSync,
+		Projects:  make([]SyncedProject, 0, len(r.Projects)),
+		Tasks:     make([]SyncedTask, 0, len(r.Items)),
+		Sections:  make([]SyncedSection, 0, len(r.Sections)),
+		Labels:    make([]SyncedLabel, 0, len(r.Labels)),
+		Comments:  make([]SyncedComment, 0, len(r.Notes)+len(r.ProjectNotes)),
+		Reminders: make([]SyncedReminder, 0, len(r.Reminders)),
+		Filters:   make([]SyncedFilter, 0, len(r.Filters)),
+	}
+	for i := range r.Projects {
+		result.Projects = append(result.Projects, SyncedProject{
+			Project:   r.Projects[i].toProject(),
+			IsDeleted: bool(r.Projects[i].IsDeleted),
+			Collapsed: bool(r.Projects[i].Collapsed),
+		})
+	}
+	for i := range r.Items {
+		result.Tasks = append(result.Tasks, SyncedTask{
+			Task:          r.Items[i].toTask(),
+			IsDeleted:     bool(r.Items[i].IsDeleted),
+			InHistory:     bool(r.Items[i].InHistory),
+			DayOrder:      r.Items[i].DayOrder,
+			Collapsed:     bool(r.Items[i].Collapsed),
+			DateAdded:     r.Items[i].DateAdded,
+			DateCompleted: r.Items[i].DateCompleted,
+		})
+	}
+	for i := range r.Sections {
+		result.Sections = append(result.Sections, SyncedSection{
+			Section:      r.Sections[i].toSection(),
+			IsDeleted:    bool(r.Sections[i].IsDeleted),
+			Collapsed:    bool(r.Sections[i].Collapsed),
+			DateArchived: r.Sections[i].DateArchived,
+		})
+	}
+	for i := range r.Labels {
+		result.Labels = append(result.Labels, SyncedLabel{
+			Label:     r.Labels[i].toLabel(),
+			IsDeleted: bool(r.Labels[i].IsDeleted),
+		})
+	}
+	for _, notes := range [][]syncNote{r.Notes, r.ProjectNotes} {
+		for i := range notes {
+			result.Comments = append(result.Comments, SyncedComment{
+				Comment:   notes[i].toComment(),
+				IsDeleted: bool(notes[i].IsDeleted),
+			})
+		}
+	}
+	for i := range r.Reminders {
+		result.Reminders = append(result.Reminders, SyncedReminder{
+			Reminder:  r.Reminders[i].toReminder(),
+			IsDeleted: bool(r.Reminders[i].IsDeleted),
+		})
+	}
+	for i := range r.Filters {
+		result.Filters = append(result.Filters, SyncedFilter{
+			Filter:    r.Filters[i].toFilter(),
+			IsDeleted: bool(r.Filters[i].IsDeleted),
+		})
+	}
+	return result
+}
+
+// Sync reads the requested resource types from the Sync API. Pass an empty sync token for a full sync, or the token of an earlier result
+// to receive only what changed since. With no resource types, all of them are requested. https://developer.todoist.com/sync/v8/#read-resources
+func (c *Client) Sync(syncToken string, resourceTypes ...SyncResourceType) (*SyncResult, error) {
+	return c.SyncWithContext(context.Background(), syncToken, resourceTypes...)
+}
+
+// SyncWithContext is Sync with a context that can cancel the call or set its deadline
+func (c *Client) SyncWithContext(ctx context.Context, syncToken string, resourceTypes ...SyncResourceType) (*SyncResult, error) {
+	if syncToken == "" {
+		syncToken = fullSyncToken
+	}
+	if len(resourceTypes) == 0 {
+		resourceTypes = []SyncResourceType{SyncResourceAll}
+	}
+	encodedTypes, err := json.Marshal(resourceTypes)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.makeCall(ctx, EndpointNameSync, map[string]string{}, map[string]string{
+		"sync_token":     syncToken,
+		"resource_types": string(encodedTypes),
+	})
+	if err != nil {
+		return nil, err
+	}
+	decoded := &syncResponse{}
+	if err = json.Unmarshal(resp.Body, decoded); err != nil {
+		return nil, err
+	}
+	return decoded.toResult(), nil
+}
+
+// SyncTokenStore persists the sync token between syncs, so a restarted process can continue incrementally instead of starting over
+type SyncTokenStore interface {
+	// LoadSyncToken returns the saved token, or an empty string if there is none
+	LoadSyncToken() (string, error)
+	// SaveSyncToken saves the token of the latest sync
+	SaveSyncToken(token string) error
+}
+
+// FileSyncTokenStore keeps the sync token in a file. The file is replaced atomically, so a crash never leaves a partial token behind.
+type FileSyncTokenStore struct {
+	Path string
+}
+
+// LoadSyncToken reads the token from the file; a missing file means there is no token yet
+func (s *FileSyncTokenStore) LoadSyncToken() (string, error) {
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// SaveSyncToken writes the token to the file
+func (s *FileSyncTokenStore) SaveSyncToken(token string) error {
+	return writeFileAtomic(s.Path, []byte(token))
+}
+
+// writeFileAtomic writes the data to a temporary file next to the path and renames it into place
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// SyncClient keeps track of the sync token across syncs, so each Sync only returns what changed since the previous one
+type SyncClient struct {
+	client        *Client
+	store         SyncTokenStore
+	resourceTypes []SyncResourceType
+
+	lock      sync.Mutex
+	syncToken string
+	loaded    bool
+}
+
+// NewSyncClient creates a SyncClient for the resource types; with none, all of them are synced. The store may be nil, in which case the
+// token is only kept in memory.
+func (c *Client) NewSyncClient(store SyncTokenStore, resourceTypes ...SyncResourceType) *SyncClient {
+	return &SyncClient{
+		client:        c,
+		store:         store,
+		resourceTypes: resourceTypes,
+	}
+}
+
+// SyncToken returns the token of the latest sync, loading it from the store if needed
+func (s *SyncClient) SyncToken() (string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if err := s.load(); err != nil {
+		return "", err
+	}
+	return s.syncToken, nil
+}
+
+// Sync returns the changes since the previous sync, or everything if there was none, and saves the new sync token
+func (s *SyncClient) Sync() (*SyncResult, error) {
+	return s.SyncWithContext(context.Background())
+}
+
+// SyncWithContext is Sync with a context that can cancel the call or set its deadline
+func (s *SyncClient) SyncWithContext(ctx context.Context) (*SyncResult, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	return s.sync(ctx, s.syncToken)
+}
+
+// FullSync returns everything, regardless of earlier syncs, and saves the new sync token
+func (s *SyncClient) FullSync() (*SyncResult, error) {
+	return s.FullSyncWithContext(context.Background())
+}
+
+// FullSyncWithContext is FullSync with a context that can cancel the call or set its deadline
+func (s *SyncClient) FullSyncWithContext(ctx context.Context) (*SyncResult, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.loaded = true
+	return s.sync(ctx, fullSyncToken)
+}
+
+// sync performs the sync and records the new token; the lock must be held
+func (s *SyncClient) sync(ctx context.Context, syncToken string) (*SyncResult, error) {
+	result, err := s.client.SyncWithContext(ctx, syncToken, s.resourceTypes...)
+	if err != nil {
+		return nil, err
+	}
+	if s.store != nil {
+		if err = s.store.SaveSyncToken(result.SyncToken); err != nil {
+			return nil, err
+		}
+	}
+	s.syncToken = result.SyncToken
+	return result, nil
+}
+
+// load reads the token from the store the first time it is needed; the lock must be held
+func (s *SyncClient) load() error {
+	if s.loaded {
+		return nil
+	}
+	if s.store != nil {
+		token, err := s.store.LoadSyncToken()
+		if err != nil {
+			return err
+		}
+		s.syncToken = token
+	}
+	s.loaded = true
+	return nil
+}
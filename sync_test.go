@@ -0,0 +1,194 @@
+package todoist
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testFullSyncResponse = `{
+	"sync_token": "token-1",
+	"full_sync": true,
+	"projects": [
+		{"id": 1, "name": "Inbox", "color": 48, "inbox_project": true, "child_order": 0, "is_deleted": 0, "is_archived": 0},
+		{"id": 2, "name": "Work", "color": 41, "parent_id": null, "child_order": 1, "is_favorite": 1, "collapsed": 1}
+	],
+	"items": [
+		{"id": 10, "project_id": 2, "section_id": 20, "content": "Write report", "priority": 4, "labels": [30], "child_order": 1, "day_order": 2, "checked": 0, "in_history": 0, "date_added": "2021-06-01T10:00:00Z",
+		 "due": {"date": "2021-06-02", "string": "tomorrow", "is_recurring": false}}
+	],
+	"sections": [
+		{"id": 20, "name": "Doing", "project_id": 2, "section_order": 1}
+	],
+	"labels": [
+		{"id": 30, "name": "waiting", "color": 47, "item_order": 1}
+	],
+	"notes": [
+		{"id": 40, "item_id": 10, "project_id": 2, "content": "First draft attached"}
+	],
+	"project_notes": [
+		{"id": 41, "project_id": 2, "content": "Kickoff notes"}
+	]
+}`
+
+const testIncrementalSyncResponse = `{
+	"sync_token": "token-2",
+	"full_sync": false,
+	"items": [
+		{"id": 10, "project_id": 2, "content": "Write report", "checked": 1, "in_history": 1, "date_completed": "2021-06-02T09:00:00Z"},
+		{"id": 11, "project_id": 2, "content": "Gone", "is_deleted": 1}
+	],
+	"projects": [
+		{"id": 3, "name": "Old client", "is_archived": 1}
+	]
+}`
+
+// syncTestServer answers syncs with the full response for the "*" token and the incremental one for any other, recording the token and
+// resource types of each sync. Anything else is refused, so the test fails on its own goroutine.
+type syncTestServer struct {
+	*httptest.Server
+	lock     sync.Mutex
+	recorded []map[string]string
+}
+
+func newSyncTestServer() *syncTestServer {
+	server := &syncTestServer{}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/sync/v8/sync" || r.Method != http.MethodPost {
+			http.Error(w, "unexpected request", http.StatusNotFound)
+			return
+		}
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		server.lock.Lock()
+		server.recorded = append(server.recorded, map[string]string{
+			"sync_token":     r.PostForm.Get("sync_token"),
+			"resource_types": r.PostForm.Get("resource_types"),
+		})
+		server.lock.Unlock()
+		if r.PostForm.Get("sync_token") == "*" {
+			w.Write([]byte(testFullSyncResponse))
+			return
+		}
+		w.Write([]byte(testIncrementalSyncResponse))
+	}))
+	return server
+}
+
+// requests returns the syncs received so far
+func (s *syncTestServer) requests() []map[string]string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]map[string]string{}, s.recorded...)
+}
+
+func TestClientSync(t *testing.T) {
+	server := newSyncTestServer()
+	defer server.Close()
+	client := NewClient("sync-test", WithBaseURL(server.URL))
+
+	result, err := client.Sync("", SyncResourceProjects, SyncResourceItems)
+	require.Nil(t, err)
+	require.Len(t, server.requests(), 1)
+	assert.Equal(t, "*", server.requests()[0]["sync_token"])
+	assert.Equal(t, `["projects","items"]`, server.requests()[0]["resource_types"])
+
+	assert.Equal(t, "token-1", result.SyncToken)
+	assert.True(t, result.FullSync)
+	require.Len(t, result.Projects, 2)
+	assert.True(t, result.Projects[0].InboxProject)
+	assert.Equal(t, "Work", result.Projects[1].Name)
+	assert.Equal(t, ColorBlue, result.Projects[1].Color)
+	assert.True(t, result.Projects[1].Favorite)
+	assert.True(t, result.Projects[1].Collapsed)
+	require.Len(t, result.Tasks, 1)
+	assert.Equal(t, "Write report", result.Tasks[0].Content)
+	assert.Equal(t, int64(20), result.Tasks[0].SectionID)
+	assert.Equal(t, []int64{30}, result.Tasks[0].LabelIDs)
+	assert.Equal(t, "2021-06-02", result.Tasks[0].Due.Date)
+	assert.Equal(t, int64(2), result.Tasks[0].DayOrder)
+	assert.False(t, result.Tasks[0].IsDeleted)
+	require.Len(t, result.Sections, 1)
+	assert.Equal(t, "Doing", result.Sections[0].Name)
+	require.Len(t, result.Labels, 1)
+	assert.Equal(t, "waiting", result.Labels[0].Name)
+	require.Len(t, result.Comments, 2)
+	assert.Equal(t, int64(10), result.Comments[0].TaskID)
+	assert.Equal(t, int64(2), result.Comments[1].ProjectID)
+
+	result, err = client.Sync(result.SyncToken)
+	require.Nil(t, err)
+	assert.Equal(t, "token-1", server.requests()[1]["sync_token"])
+	assert.Equal(t, `["all"]`, server.requests()[1]["resource_types"])
+	assert.False(t, result.FullSync)
+	require.Len(t, result.Tasks, 2)
+	assert.True(t, result.Tasks[0].Completed)
+	assert.True(t, result.Tasks[0].InHistory)
+	assert.Equal(t, "2021-06-02T09:00:00Z", result.Tasks[0].DateCompleted)
+	assert.True(t, result.Tasks[1].IsDeleted)
+	require.Len(t, result.Projects, 1)
+	assert.True(t, result.Projects[0].IsArchived)
+	assert.Empty(t, result.Sections)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = client.SyncWithContext(ctx, "")
+	assert.True(t, errors.Is(err, context.Canceled))
+}
+
+func TestSyncClientResumes(t *testing.T) {
+	server := newSyncTestServer()
+	defer server.Close()
+	client := NewClient("sync-test", WithBaseURL(server.URL), WithRetryPolicy(RetryPolicy{}))
+	store := &FileSyncTokenStore{Path: filepath.Join(t.TempDir(), "sync_token")}
+
+	syncer := client.NewSyncClient(store, SyncResourceItems)
+	token, err := syncer.SyncToken()
+	assert.Nil(t, err)
+	assert.Equal(t, "", token)
+	result, err := syncer.Sync()
+	require.Nil(t, err)
+	assert.True(t, result.FullSync)
+	token, _ = syncer.SyncToken()
+	assert.Equal(t, "token-1", token)
+
+	// a new process picks up where the last one stopped
+	syncer = client.NewSyncClient(store, SyncResourceItems)
+	result, err = syncer.Sync()
+	require.Nil(t, err)
+	assert.False(t, result.FullSync)
+	assert.Equal(t, "token-1", server.requests()[1]["sync_token"])
+	saved, err := os.ReadFile(store.Path)
+	require.Nil(t, err)
+	assert.Equal(t, "token-2", string(saved))
+
+	// a full sync starts over
+	result, err = syncer.FullSync()
+	require.Nil(t, err)
+	assert.True(t, result.FullSync)
+	assert.Equal(t, "*", server.requests()[2]["sync_token"])
+
+	// without a store, the token only lives in memory
+	syncer = client.NewSyncClient(nil)
+	_, err = syncer.Sync()
+	require.Nil(t, err)
+	token, _ = syncer.SyncToken()
+	assert.Equal(t, "token-1", token)
+
+	// a failed sync keeps the previous token
+	server.Close()
+	_, err = syncer.Sync()
+	assert.NotNil(t, err)
+	token, _ = syncer.SyncToken()
+	assert.Equal(t, "token-1", token)
+}
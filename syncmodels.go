@@ -0,0 +1,301 @@
+package todoist
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The Sync API, which also shapes webhook payloads, names and encodes its fields differently from the REST API. The types here decode
+// the Sync API objects and convert them to the SDK's entity structs, so callers only ever work with Task, Project, Section, Label, and
+// Comment.
+
+// syncBool decodes a boolean that the Sync API may send as true/false or as 1/0
+type syncBool bool
+
+func (b *syncBool) UnmarshalJSON(data []byte) error {
+	switch string(bytes.Trim(data, `"`)) {
+	case "true", "1":
+		*b = true
+	case "false", "0", "null", "":
+		*b = false
+	default:
+		return fmt.Errorf("cannot decode %s as a boolean", string(data))
+	}
+	return nil
+}
+
+// syncInt64 decodes an id that the Sync API may send as a number or as a string
+type syncInt64 int64
+
+func (i *syncInt64) UnmarshalJSON(data []byte) error {
+	trimmed := string(bytes.Trim(data, `"`))
+	if trimmed == "null" || trimmed == "" {
+		*i = 0
+		return nil
+	}
+	value, err := strconv.ParseInt(trimmed, 10, 64)
+	if err != nil {
+		return fmt.Errorf("cannot decode %s as an integer", string(data))
+	}
+	*i = syncInt64(value)
+	return nil
+}
+
+// syncFloat64 decodes a number that the Sync API may send as a number or as a string, such as the coordinates of a location
+type syncFloat64 float64
+
+func (f *syncFloat64) UnmarshalJSON(data []byte) error {
+	trimmed := string(bytes.Trim(data, `"`))
+	if trimmed == "null" || trimmed == "" {
+		*f = 0
+		return nil
+	}
+	value, err := strconv.ParseFloat(trimmed, 64)
+	if err != nil {
+		return fmt.Errorf("cannot decode %s as a number", string(data))
+	}
+	*f = syncFloat64(value)
+	return nil
+}
+
+// syncDue is the due object of the Sync API, where the date holds either a date or a date and time
+type syncDue struct {
+	Date        string   `json:"date"`
+	Timezone    *string  `json:"timezone"`
+	String      string   `json:"string"`
+	Lang        string   `json:"lang"`
+	IsRecurring syncBool `json:"is_recurring"`
+}
+
+func (d *syncDue) toTaskDueInfo() TaskDueInfo {
+	if d == nil {
+		return TaskDueInfo{}
+	}
+	due := TaskDueInfo{
+		Date:      d.Date,
+		Recurring: bool(d.IsRecurring),
+		String:    d.String,
+		Timezone:  StringValue(d.Timezone),
+	}
+	if strings.Contains(d.Date, "T") {
+		due.Datetime = d.Date
+		due.Date = d.Date[:strings.Index(d.Date, "T")]
+	}
+	return due
+}
+
+// syncItem is a task as the Sync API represents it
+type syncItem struct {
+	ID             int64    `json:"id"`
+	UserID         int64    `json:"user_id"`
+	ProjectID      int64    `json:"project_id"`
+	SectionID      int64    `json:"section_id"`
+	ParentID       int64    `json:"parent_id"`
+	Content        string   `json:"content"`
+	Description    string   `json:"description"`
+	Priority       Priority `json:"priority"`
+	Due            *syncDue `json:"due"`
+	ChildOrder     int64    `json:"child_order"`
+	DayOrder       int64    `json:"day_order"`
+	Collapsed      syncBool `json:"collapsed"`
+	Labels         []int64  `json:"labels"`
+	AddedByUID     int64    `json:"added_by_uid"`
+	AssignedByUID  int64    `json:"assigned_by_uid"`
+	ResponsibleUID int64    `json:"responsible_uid"`
+	Checked        syncBool `json:"checked"`
+	InHistory      syncBool `json:"in_history"`
+	IsDeleted      syncBool `json:"is_deleted"`
+	SyncID         int64    `json:"sync_id"`
+	DateCompleted  string   `json:"date_completed"`
+	DateAdded      string   `json:"date_added"`
+}
+
+func (i *syncItem) toTask() Task {
+	labels := i.Labels
+	if labels == nil {
+		labels = []int64{}
+	}
+	return Task{
+		ID:          i.ID,
+		ProjectID:   i.ProjectID,
+		SectionID:   i.SectionID,
+		Content:     i.Content,
+		Description: i.Description,
+		Completed:   bool(i.Checked),
+		LabelIDs:    labels,
+		ParentID:    i.ParentID,
+		Order:       i.ChildOrder,
+		Priority:    i.Priority,
+		Due:         i.Due.toTaskDueInfo(),
+		URL:         fmt.Sprintf("https://todoist.com/showTask?id=%d", i.ID),
+		Assignee:    i.ResponsibleUID,
+		Assigner:    i.AssignedByUID,
+	}
+}
+
+// syncProject is a project as the Sync API represents it
+type syncProject struct {
+	ID           int64    `json:"id"`
+	Name         string   `json:"name"`
+	Color        Color    `json:"color"`
+	ParentID     int64    `json:"parent_id"`
+	ChildOrder   int64    `json:"child_order"`
+	Collapsed    syncBool `json:"collapsed"`
+	Shared       syncBool `json:"shared"`
+	IsDeleted    syncBool `json:"is_deleted"`
+	IsArchived   syncBool `json:"is_archived"`
+	IsFavorite   syncBool `json:"is_favorite"`
+	SyncID       int64    `json:"sync_id"`
+	InboxProject syncBool `json:"inbox_project"`
+	TeamInbox    syncBool `json:"team_inbox"`
+}
+
+func (p *syncProject) toProject() Project {
+	return Project{
+		ID:           p.ID,
+		Name:         p.Name,
+		Order:        p.ChildOrder,
+		Color:        p.Color,
+		Shared:       bool(p.Shared),
+		SyncID:       p.SyncID,
+		Favorite:     bool(p.IsFavorite),
+		InboxProject: bool(p.InboxProject),
+		URL:          fmt.Sprintf("https://todoist.com/showProject?id=%d", p.ID),
+		TeamInbox:    bool(p.TeamInbox),
+		ParentID:     p.ParentID,
+		IsArchived:   bool(p.IsArchived),
+	}
+}
+
+// syncSection is a section as the Sync API represents it
+type syncSection struct {
+	ID           int64    `json:"id"`
+	Name         string   `json:"name"`
+	ProjectID    int64    `json:"project_id"`
+	SectionOrder int64    `json:"section_order"`
+	Collapsed    syncBool `json:"collapsed"`
+	SyncID       int64    `json:"sync_id"`
+	IsDeleted    syncBool `json:"is_deleted"`
+	IsArchived   syncBool `json:"is_archived"`
+	DateArchived string   `json:"date_archived"`
+	DateAdded    string   `json:"date_added"`
+}
+
+func (s *syncSection) toSection() Section {
+	return Section{
+		ID:         s.ID,
+		ProjectID:  s.ProjectID,
+		Name:       s.Name,
+		Order:      s.SectionOrder,
+		IsArchived: bool(s.IsArchived),
+	}
+}
+
+// syncLabel is a label as the Sync API represents it
+type syncLabel struct {
+	ID         int64    `json:"id"`
+	Name       string   `json:"name"`
+	Color      int64    `json:"color"`
+	ItemOrder  int64    `json:"item_order"`
+	IsDeleted  syncBool `json:"is_deleted"`
+	IsFavorite syncBool `json:"is_favorite"`
+}
+
+func (l *syncLabel) toLabel() Label {
+	return Label{
+		ID:       l.ID,
+		Name:     l.Name,
+		Color:    l.Color,
+		Order:    l.ItemOrder,
+		Favorite: bool(l.IsFavorite),
+	}
+}
+
+// syncNote is a comment as the Sync API represents it; notes on tasks have an item id, and project notes only a project id
+type syncNote struct {
+	ID             int64              `json:"id"`
+	PostedUID      int64              `json:"posted_uid"`
+	ItemID         int64              `json:"item_id"`
+	ProjectID      int64              `json:"project_id"`
+	Content        string             `json:"content"`
+	FileAttachment *CommentAttachment `json:"file_attachment"`
+	IsDeleted      syncBool           `json:"is_deleted"`
+	Posted         string             `json:"posted"`
+}
+
+func (n *syncNote) toComment() Comment {
+	comment := Comment{
+		ID:         n.ID,
+		TaskID:     n.ItemID,
+		Posted:     n.Posted,
+		Content:    n.Content,
+		Attachment: n.FileAttachment,
+	}
+	if n.ItemID == 0 {
+		comment.ProjectID = n.ProjectID
+	}
+	return comment
+}
+
+// syncReminder is a reminder as the Sync API represents it
+type syncReminder struct {
+	ID           int64           `json:"id"`
+	NotifyUID    int64           `json:"notify_uid"`
+	ItemID       int64           `json:"item_id"`
+	Type         ReminderType    `json:"type"`
+	Due          *syncDue        `json:"due"`
+	MinuteOffset int64           `json:"minute_offset"`
+	Name         string          `json:"name"`
+	LocLat       syncFloat64     `json:"loc_lat"`
+	LocLong      syncFloat64     `json:"loc_long"`
+	LocTrigger   LocationTrigger `json:"loc_trigger"`
+	Radius       int64           `json:"radius"`
+	IsDeleted    syncBool        `json:"is_deleted"`
+}
+
+func (r *syncReminder) toReminder() Reminder {
+	reminder := Reminder{
+		ID:           r.ID,
+		TaskID:       r.ItemID,
+		NotifyUID:    r.NotifyUID,
+		Type:         r.Type,
+		MinuteOffset: r.MinuteOffset,
+	}
+	switch r.Type {
+	case ReminderTypeAbsolute:
+		reminder.Due = r.Due.toTaskDueInfo()
+	case ReminderTypeLocation:
+		reminder.Location = &ReminderLocation{
+			Name:      r.Name,
+			Latitude:  float64(r.LocLat),
+			Longitude: float64(r.LocLong),
+			Radius:    r.Radius,
+			Trigger:   r.LocTrigger,
+		}
+	}
+	return reminder
+}
+
+// syncFilter is a saved filter as the Sync API represents it
+type syncFilter struct {
+	ID         int64    `json:"id"`
+	Name       string   `json:"name"`
+	Query      string   `json:"query"`
+	Color      Color    `json:"color"`
+	ItemOrder  int64    `json:"item_order"`
+	IsFavorite syncBool `json:"is_favorite"`
+	IsDeleted  syncBool `json:"is_deleted"`
+}
+
+func (f *syncFilter) toFilter() Filter {
+	return Filter{
+		ID:       f.ID,
+		Name:     f.Name,
+		Query:    f.Query,
+		Color:    f.Color,
+		Order:    f.ItemOrder,
+		Favorite: bool(f.IsFavorite),
+	}
+}
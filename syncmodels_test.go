@@ -0,0 +1,57 @@
+package todoist
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncBool(t *testing.T) {
+	cases := map[string]bool{
+		`true`:  true,
+		`1`:     true,
+		`"1"`:   true,
+		`false`: false,
+		`0`:     false,
+		`null`:  false,
+	}
+	for input, expected := range cases {
+		var b syncBool
+		assert.Nil(t, json.Unmarshal([]byte(input), &b), input)
+		assert.Equal(t, expected, bool(b), input)
+	}
+	var b syncBool
+	assert.NotNil(t, json.Unmarshal([]byte(`"yes"`), &b))
+}
+
+func TestSyncInt64(t *testing.T) {
+	for input, expected := range map[string]int64{`12`: 12, `"2203306141"`: 2203306141, `null`: 0, `""`: 0} {
+		var i syncInt64
+		assert.Nil(t, json.Unmarshal([]byte(input), &i), input)
+		assert.Equal(t, expected, int64(i), input)
+	}
+	var i syncInt64
+	assert.NotNil(t, json.Unmarshal([]byte(`"twelve"`), &i))
+}
+
+func TestSyncFloat64(t *testing.T) {
+	for input, expected := range map[string]float64{`41.148581`: 41.148581, `"-8.6105"`: -8.6105, `null`: 0, `""`: 0} {
+		var f syncFloat64
+		assert.Nil(t, json.Unmarshal([]byte(input), &f), input)
+		assert.Equal(t, expected, float64(f), input)
+	}
+	var f syncFloat64
+	assert.NotNil(t, json.Unmarshal([]byte(`"north"`), &f))
+}
+
+func TestSyncDueConversion(t *testing.T) {
+	var due *syncDue
+	assert.Equal(t, TaskDueInfo{}, due.toTaskDueInfo())
+
+	due = &syncDue{Date: "2021-06-01", String: "every day", IsRecurring: true}
+	assert.Equal(t, TaskDueInfo{Date: "2021-06-01", String: "every day", Recurring: true}, due.toTaskDueInfo())
+
+	due = &syncDue{Date: "2021-06-01T17:00:00Z", Timezone: String("Europe/Berlin")}
+	assert.Equal(t, TaskDueInfo{Date: "2021-06-01", Datetime: "2021-06-01T17:00:00Z", Timezone: "Europe/Berlin"}, due.toTaskDueInfo())
+}
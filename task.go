@@ -1,10 +1,12 @@
 package todoist
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 )
 
 // Task represents a single todo item to track
@@ -35,6 +37,65 @@ type TaskDueInfo struct {
 	Timezone  string `json:"timezone" db:"timezone"`
 }
 
+// TaskListOptions narrow down the active tasks to get. The server applies only one kind of option, so a filter, ids, and the project,
+// section, and label ids cannot be combined; the project, section, and label ids can be combined with each other.
+type TaskListOptions struct {
+	ProjectID int64
+	SectionID int64
+	LabelID   int64
+	Filter    string  // a filter query, evaluated by the server
+	Lang      string  // the language of the filter query, such as "en"
+	IDs       []int64 // specific tasks
+}
+
+// Validate checks the options for combinations the server would silently ignore. Nil options are valid.
+func (o *TaskListOptions) Validate() error {
+	if o == nil {
+		return nil
+	}
+	byParent := o.ProjectID != 0 || o.SectionID != 0 || o.LabelID != 0
+	if o.Filter != "" && (byParent || len(o.IDs) > 0) {
+		return errors.New("filter cannot be combined with ids or a project, section, or label id")
+	}
+	if len(o.IDs) > 0 && byParent {
+		return errors.New("ids cannot be combined with a project, section, or label id")
+	}
+	if o.Lang != "" && o.Filter == "" {
+		return errors.New("lang only applies to a filter")
+	}
+	return nil
+}
+
+func (o *TaskListOptions) queryParams() map[string]string {
+	data := map[string]string{}
+	if o == nil {
+		return data
+	}
+	if o.ProjectID != 0 {
+		data["project_id"] = fmt.Sprintf("%d", o.ProjectID)
+	}
+	if o.SectionID != 0 {
+		data["section_id"] = fmt.Sprintf("%d", o.SectionID)
+	}
+	if o.LabelID != 0 {
+		data["label_id"] = fmt.Sprintf("%d", o.LabelID)
+	}
+	if o.Filter != "" {
+		data["filter"] = o.Filter
+	}
+	if o.Lang != "" {
+		data["lang"] = o.Lang
+	}
+	if len(o.IDs) > 0 {
+		ids := make([]string, len(o.IDs))
+		for i := range o.IDs {
+			ids[i] = fmt.Sprintf("%d", o.IDs[i])
+		}
+		data["ids"] = strings.Join(ids, ",")
+	}
+	return data
+}
+
 // TaskParams are the fields you can set when creating or updating
 type TaskParams struct {
 	ProjectID    *int64   `json:"project_id,omitempty" db:"project_id"`
@@ -58,10 +119,129 @@ type TaskParams struct {
 	DueDatetime *string `json:"due_datetime" db:"due_datetime"`
 }
 
+// checkDue checks that at most one of the due date and due datetime is set, since both are sent as the date of the due
+func (p *TaskParams) checkDue() error {
+	if p != nil && p.DueDate != nil && p.DueDatetime != nil {
+		return errors.New("only one of the due date and due datetime can be set")
+	}
+	return nil
+}
+
 // GetActiveTasks gets the active tasks for a user. https://developer.todoist.com/rest/v1/#get-active-tasks
 func GetActiveTasks(token string) ([]Task, error) {
+	return clientForToken(token).GetActiveTasks()
+}
+
+// GetActiveTasksWithContext is GetActiveTasks with a context that can cancel the call or set its deadline
+func GetActiveTasksWithContext(ctx context.Context, token string) ([]Task, error) {
+	return clientForToken(token).GetActiveTasksWithContext(ctx)
+}
+
+// ListActiveTasks gets the active tasks for a user that match the options. https://developer.todoist.com/rest/v1/#get-active-tasks
+func ListActiveTasks(token string, opts *TaskListOptions) ([]Task, error) {
+	return clientForToken(token).ListActiveTasks(opts)
+}
+
+// ListActiveTasksWithContext is ListActiveTasks with a context that can cancel the call or set its deadline
+func ListActiveTasksWithContext(ctx context.Context, token string, opts *TaskListOptions) ([]Task, error) {
+	return clientForToken(token).ListActiveTasksWithContext(ctx, opts)
+}
+
+// CreateTask creates a returns a new task. The only required field is the content field. https://developer.todoist.com/rest/v1/#create-a-new-task
+func CreateTask(token string, input *TaskParams) (*Task, error) {
+	return clientForToken(token).CreateTask(input)
+}
+
+// CreateTaskWithContext is CreateTask with a context that can cancel the call or set its deadline
+func CreateTaskWithContext(ctx context.Context, token string, input *TaskParams) (*Task, error) {
+	return clientForToken(token).CreateTaskWithContext(ctx, input)
+}
+
+// GetActiveTask gets a single task by its id. https://developer.todoist.com/rest/v1/#get-an-active-task
+func GetActiveTask(token string, taskID int64) (*Task, error) {
+	return clientForToken(token).GetActiveTask(taskID)
+}
+
+// GetActiveTaskWithContext is GetActiveTask with a context that can cancel the call or set its deadline
+func GetActiveTaskWithContext(ctx context.Context, token string, taskID int64) (*Task, error) {
+	return clientForToken(token).GetActiveTaskWithContext(ctx, taskID)
+}
+
+// UpdateTask updates a task. Todoist ignores the project, section, and parent on update; use MoveTask to change them.
+// https://developer.todoist.com/rest/v1/#update-a-task
+func UpdateTask(token string, taskID int64, newData *TaskParams) (*Task, error) {
+	return clientForToken(token).UpdateTask(taskID, newData)
+}
+
+// UpdateTaskWithContext is UpdateTask with a context that can cancel the call or set its deadline
+func UpdateTaskWithContext(ctx context.Context, token string, taskID int64, newData *TaskParams) (*Task, error) {
+	return clientForToken(token).UpdateTaskWithContext(ctx, taskID, newData)
+}
+
+// DeleteTask deletes a task. You probably want to close it instead? https://developer.todoist.com/rest/v1/#delete-a-task
+func DeleteTask(token string, taskID int64) error {
+	return clientForToken(token).DeleteTask(taskID)
+}
+
+// DeleteTaskWithContext is DeleteTask with a context that can cancel the call or set its deadline
+func DeleteTaskWithContext(ctx context.Context, token string, taskID int64) error {
+	return clientForToken(token).DeleteTaskWithContext(ctx, taskID)
+}
+
+// CloseTask closes a task. According to the docs, this will cause root tasks to be marked complete and moved to the history. https://developer.todoist.com/rest/v1/#close-a-task
+func CloseTask(token string, taskID int64) error {
+	return clientForToken(token).CloseTask(taskID)
+}
+
+// CloseTaskWithContext is CloseTask with a context that can cancel the call or set its deadline
+func CloseTaskWithContext(ctx context.Context, token string, taskID int64) error {
+	return clientForToken(token).CloseTaskWithContext(ctx, taskID)
+}
+
+// ReopenTask reopens a closed task. https://developer.todoist.com/rest/v1/#reopen-a-task
+func ReopenTask(token string, taskID int64) error {
+	return clientForToken(token).ReopenTask(taskID)
+}
+
+// ReopenTaskWithContext is ReopenTask with a context that can cancel the call or set its deadline
+func ReopenTaskWithContext(ctx context.Context, token string, taskID int64) error {
+	return clientForToken(token).ReopenTaskWithContext(ctx, taskID)
+}
+
+// MoveTask moves a task, along with its subtasks, to the project, section, or parent task of the placement, of which exactly one must be
+// set, and returns the moved task. https://developer.todoist.com/sync/v8/#move-an-item
+func MoveTask(token string, taskID int64, placement Placement) (*Task, error) {
+	return clientForToken(token).MoveTask(taskID, placement)
+}
+
+// MoveTaskWithContext is MoveTask with a context that can cancel the call or set its deadline
+func MoveTaskWithContext(ctx context.Context, token string, taskID int64, placement Placement) (*Task, error) {
+	return clientForToken(token).MoveTaskWithContext(ctx, taskID, placement)
+}
+
+// GetActiveTasks gets the active tasks for a user. https://developer.todoist.com/rest/v1/#get-active-tasks
+func (c *Client) GetActiveTasks() ([]Task, error) {
+	return c.GetActiveTasksWithContext(context.Background())
+}
+
+// GetActiveTasksWithContext is GetActiveTasks with a context that can cancel the call or set its deadline
+func (c *Client) GetActiveTasksWithContext(ctx context.Context) ([]Task, error) {
+	return c.ListActiveTasksWithContext(ctx, nil)
+}
+
+// ListActiveTasks gets the active tasks for a user that match the options. With nil options, it gets all of them, like
+// GetActiveTasks. https://developer.todoist.com/rest/v1/#get-active-tasks
+func (c *Client) ListActiveTasks(opts *TaskListOptions) ([]Task, error) {
+	return c.ListActiveTasksWithContext(context.Background(), opts)
+}
+
+// ListActiveTasksWithContext is ListActiveTasks with a context that can cancel the call or set its deadline
+func (c *Client) ListActiveTasksWithContext(ctx context.Context, opts *TaskListOptions) ([]Task, error) {
 	tasks := []Task{}
-	resp, err := makeCall(token, EndpointNameGetAllActiveTasks, map[string]string{}, nil)
+	if err := opts.Validate(); err != nil {
+		return tasks, err
+	}
+	resp, err := c.makeCall(ctx, EndpointNameGetAllActiveTasks, map[string]string{}, opts.queryParams())
 	if err != nil {
 		return tasks, err
 	}
@@ -70,14 +250,22 @@ func GetActiveTasks(token string) ([]Task, error) {
 }
 
 // CreateTask creates a returns a new task. The only required field is the content field. https://developer.todoist.com/rest/v1/#create-a-new-task
-func CreateTask(token string, input *TaskParams) (*Task, error) {
+func (c *Client) CreateTask(input *TaskParams) (*Task, error) {
+	return c.CreateTaskWithContext(context.Background(), input)
+}
+
+// CreateTaskWithContext is CreateTask with a context that can cancel the call or set its deadline
+func (c *Client) CreateTaskWithContext(ctx context.Context, input *TaskParams) (*Task, error) {
 	if input == nil {
 		return nil, errors.New("you must provide a valid input with at least a content field")
 	}
 	if input.Content == nil || StringValue(input.Content) == "" {
 		return nil, errors.New("content is required")
 	}
-	resp, err := makeCall(token, EndpointNameCreateTask, map[string]string{}, input)
+	if err := input.checkDue(); err != nil {
+		return nil, err
+	}
+	resp, err := c.makeCall(ctx, EndpointNameCreateTask, map[string]string{}, input)
 	if err != nil {
 		return nil, err
 	}
@@ -87,8 +275,13 @@ func CreateTask(token string, input *TaskParams) (*Task, error) {
 }
 
 // GetActiveTask gets a single task by its id. https://developer.todoist.com/rest/v1/#get-an-active-task
-func GetActiveTask(token string, taskID int64) (*Task, error) {
-	resp, err := makeCall(token, EndpointNameGetTask, map[string]string{
+func (c *Client) GetActiveTask(taskID int64) (*Task, error) {
+	return c.GetActiveTaskWithContext(context.Background(), taskID)
+}
+
+// GetActiveTaskWithContext is GetActiveTask with a context that can cancel the call or set its deadline
+func (c *Client) GetActiveTaskWithContext(ctx context.Context, taskID int64) (*Task, error) {
+	resp, err := c.makeCall(ctx, EndpointNameGetTask, map[string]string{
 		"id": fmt.Sprintf("%d", taskID),
 	}, nil)
 	if err != nil {
@@ -99,12 +292,21 @@ func GetActiveTask(token string, taskID int64) (*Task, error) {
 	return found, err
 }
 
-// UpdateTask updates a task. https://developer.todoist.com/rest/v1/#update-a-task
-func UpdateTask(token string, taskID int64, newData *TaskParams) (*Task, error) {
+// UpdateTask updates a task. Todoist ignores the project, section, and parent on update; use MoveTask to change them.
+// https://developer.todoist.com/rest/v1/#update-a-task
+func (c *Client) UpdateTask(taskID int64, newData *TaskParams) (*Task, error) {
+	return c.UpdateTaskWithContext(context.Background(), taskID, newData)
+}
+
+// UpdateTaskWithContext is UpdateTask with a context that can cancel the call or set its deadline
+func (c *Client) UpdateTaskWithContext(ctx context.Context, taskID int64, newData *TaskParams) (*Task, error) {
 	if newData == nil {
 		return nil, errors.New("you must pass in a valid input")
 	}
-	_, err := makeCall(token, EndpointNameUpdateTask, map[string]string{
+	if err := newData.checkDue(); err != nil {
+		return nil, err
+	}
+	_, err := c.makeCall(ctx, EndpointNameUpdateTask, map[string]string{
 		"id": fmt.Sprintf("%d", taskID),
 	}, newData)
 	if err != nil {
@@ -112,12 +314,17 @@ func UpdateTask(token string, taskID int64, newData *TaskParams) (*Task, error)
 	}
 	// the update itself returns nothing, so we need to
 	// get it again if we want the updated information
-	return GetActiveTask(token, taskID)
+	return c.GetActiveTaskWithContext(ctx, taskID)
 }
 
 // DeleteTask deletes a task. You probably want to close it instead? https://developer.todoist.com/rest/v1/#delete-a-task
-func DeleteTask(token string, taskID int64) error {
-	resp, err := makeCall(token, EndpointNameDeleteTask, map[string]string{
+func (c *Client) DeleteTask(taskID int64) error {
+	return c.DeleteTaskWithContext(context.Background(), taskID)
+}
+
+// DeleteTaskWithContext is DeleteTask with a context that can cancel the call or set its deadline
+func (c *Client) DeleteTaskWithContext(ctx context.Context, taskID int64) error {
+	resp, err := c.makeCall(ctx, EndpointNameDeleteTask, map[string]string{
 		"id": fmt.Sprintf("%d", taskID),
 	}, nil)
 	if err != nil {
@@ -130,8 +337,13 @@ func DeleteTask(token string, taskID int64) error {
 }
 
 // CloseTask closes a task. According to the docs, this will cause root tasks to be marked complete and moved to the history. https://developer.todoist.com/rest/v1/#close-a-task
-func CloseTask(token string, taskID int64) error {
-	resp, err := makeCall(token, EndpointNameCloseTask, map[string]string{
+func (c *Client) CloseTask(taskID int64) error {
+	return c.CloseTaskWithContext(context.Background(), taskID)
+}
+
+// CloseTaskWithContext is CloseTask with a context that can cancel the call or set its deadline
+func (c *Client) CloseTaskWithContext(ctx context.Context, taskID int64) error {
+	resp, err := c.makeCall(ctx, EndpointNameCloseTask, map[string]string{
 		"id": fmt.Sprintf("%d", taskID),
 	}, nil)
 	if err != nil {
@@ -144,8 +356,13 @@ func CloseTask(token string, taskID int64) error {
 }
 
 // ReopenTask reopens a closed task. https://developer.todoist.com/rest/v1/#reopen-a-task
-func ReopenTask(token string, taskID int64) error {
-	resp, err := makeCall(token, EndpointNameReopenTask, map[string]string{
+func (c *Client) ReopenTask(taskID int64) error {
+	return c.ReopenTaskWithContext(context.Background(), taskID)
+}
+
+// ReopenTaskWithContext is ReopenTask with a context that can cancel the call or set its deadline
+func (c *Client) ReopenTaskWithContext(ctx context.Context, taskID int64) error {
+	resp, err := c.makeCall(ctx, EndpointNameReopenTask, map[string]string{
 		"id": fmt.Sprintf("%d", taskID),
 	}, nil)
 	if err != nil {
@@ -156,3 +373,38 @@ func ReopenTask(token string, taskID int64) error {
 	}
 	return nil
 }
+
+// MoveTask moves a task, along with its subtasks, to the project, section, or parent task of the placement, of which exactly one must be
+// set, and returns the moved task. https://developer.todoist.com/sync/v8/#move-an-item
+func (c *Client) MoveTask(taskID int64, placement Placement) (*Task, error) {
+	return c.MoveTaskWithContext(context.Background(), taskID, placement)
+}
+
+// MoveTaskWithContext is MoveTask with a context that can cancel the call or set its deadline
+func (c *Client) MoveTaskWithContext(ctx context.Context, taskID int64, placement Placement) (*Task, error) {
+	if taskID == 0 {
+		return nil, errors.New("task id is required")
+	}
+	set := 0
+	for _, ref := range []Ref{placement.Project, placement.Section, placement.Parent} {
+		if ref.TempID() != "" {
+			return nil, errors.New("a task can only be moved to an existing object; use a CommandBatch to move it to a new one")
+		}
+		if !ref.IsZero() {
+			set++
+		}
+	}
+	if set != 1 {
+		return nil, errors.New("exactly one of the project, section, and parent must be set")
+	}
+	if placement.Parent.ID() == taskID {
+		return nil, errors.New("a task cannot be its own parent")
+	}
+	batch := NewCommandBatch()
+	if _, err := c.executeCommand(ctx, batch, batch.MoveTask(IDRef(taskID), placement)); err != nil {
+		return nil, err
+	}
+	// the move itself returns nothing, so we need to
+	// get it again if we want the updated information
+	return c.GetActiveTaskWithContext(ctx, taskID)
+}
@@ -3,6 +3,7 @@ package todoist
 import (
 	"fmt"
 	"math/rand"
+	"net/url"
 	"testing"
 	"time"
 
@@ -72,6 +73,19 @@ func TestTaskCRUD(t *testing.T) {
 	}
 	assert.True(t, foundInSlice)
 
+	// narrow the list down to the project, and to the task itself
+	tasks, err = ListActiveTasks(tokenToUse, &TaskListOptions{ProjectID: project.ID})
+	assert.Nil(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, created.ID, tasks[0].ID)
+	tasks, err = ListActiveTasks(tokenToUse, &TaskListOptions{IDs: []int64{created.ID}})
+	assert.Nil(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, created.ID, tasks[0].ID)
+	tasks, err = ListActiveTasks(tokenToUse, &TaskListOptions{ProjectID: project.ID, LabelID: -1})
+	assert.Nil(t, err)
+	assert.Empty(t, tasks)
+
 	found, err := GetActiveTask(tokenToUse, -1)
 	assert.NotNil(t, err)
 	require.Nil(t, found)
@@ -134,3 +148,75 @@ func TestTaskCRUD(t *testing.T) {
 	assert.NotNil(t, err)
 	assert.Nil(t, found)
 }
+
+func TestTaskListOptions(t *testing.T) {
+	assert.Nil(t, (*TaskListOptions)(nil).Validate())
+	assert.Nil(t, (&TaskListOptions{ProjectID: 1, SectionID: 2, LabelID: 3}).Validate())
+	assert.Nil(t, (&TaskListOptions{Filter: "today", Lang: "en"}).Validate())
+	assert.NotNil(t, (&TaskListOptions{Filter: "today", ProjectID: 1}).Validate())
+	assert.NotNil(t, (&TaskListOptions{Filter: "today", IDs: []int64{1}}).Validate())
+	assert.NotNil(t, (&TaskListOptions{IDs: []int64{1}, LabelID: 1}).Validate())
+	assert.NotNil(t, (&TaskListOptions{Lang: "en"}).Validate())
+
+	fake, client := newFakeTodoistClient(t)
+	fake.seed("tasks",
+		map[string]interface{}{"id": 1, "content": "First", "project_id": 2},
+		map[string]interface{}{"id": 2, "content": "Second", "project_id": 2},
+		map[string]interface{}{"id": 4, "content": "Elsewhere", "project_id": 3},
+	)
+
+	_, err := client.ListActiveTasks(&TaskListOptions{Filter: "today & p1", Lang: "en"})
+	require.Nil(t, err)
+	tasks, err := client.ListActiveTasks(&TaskListOptions{IDs: []int64{1, 2, 3}})
+	require.Nil(t, err)
+	require.Len(t, tasks, 2)
+	assert.ElementsMatch(t, []int64{1, 2}, []int64{tasks[0].ID, tasks[1].ID})
+	tasks, err = client.ListActiveTasks(&TaskListOptions{ProjectID: 3})
+	require.Nil(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "Elsewhere", tasks[0].Content)
+	_, err = client.ListActiveTasks(&TaskListOptions{Filter: "today", SectionID: 1})
+	require.NotNil(t, err)
+
+	queries := fake.listQueries["tasks"]
+	require.Len(t, queries, 3)
+	assert.Equal(t, url.Values{"filter": {"today & p1"}, "lang": {"en"}}, queries[0])
+	assert.Equal(t, url.Values{"ids": {"1,2,3"}}, queries[1])
+	assert.Equal(t, url.Values{"project_id": {"3"}}, queries[2])
+}
+
+func TestMoveTask(t *testing.T) {
+	fake, client := newFakeTodoistClient(t)
+	fake.seed("projects", map[string]interface{}{"id": 2, "name": "Work"})
+	fake.seed("sections",
+		map[string]interface{}{"id": 20, "name": "Doing", "project_id": 2},
+		map[string]interface{}{"id": 21, "name": "Done", "project_id": 2},
+	)
+	fake.seed("tasks", map[string]interface{}{"id": 1, "content": "Review", "project_id": 2, "section_id": 20})
+
+	task, err := client.MoveTask(1, Placement{Section: IDRef(21)})
+	require.Nil(t, err)
+	assert.Equal(t, int64(21), task.SectionID)
+	assert.Equal(t, []string{"item_move"}, fake.commandTypes())
+	assert.Equal(t, map[string]interface{}{"id": float64(1), "section_id": float64(21)}, fake.commands()[0]["args"])
+
+	_, err = client.MoveTask(1, Placement{Project: IDRef(404)})
+	commandErr := &CommandError{}
+	require.ErrorAs(t, err, &commandErr)
+	assert.Equal(t, "Project not found", commandErr.Message)
+
+	batch := NewCommandBatch()
+	newSection := batch.CreateSection(&SectionParams{Name: "New"}, IDRef(2))
+	for _, placement := range []Placement{
+		{},
+		{Project: IDRef(2), Section: IDRef(20)},
+		{Parent: IDRef(1)},
+		{Section: newSection.Ref()},
+	} {
+		_, err = client.MoveTask(1, placement)
+		assert.NotNil(t, err, "%+v", placement)
+	}
+	_, err = client.MoveTask(0, Placement{Project: IDRef(2)})
+	assert.NotNil(t, err)
+	assert.Len(t, fake.commands(), 2)
+}
@@ -0,0 +1,343 @@
+package todoist
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// TemplateRowType is the kind of a row in a project template
+type TemplateRowType string
+
+const (
+	TemplateRowTask    TemplateRowType = "task"
+	TemplateRowSection TemplateRowType = "section"
+	TemplateRowNote    TemplateRowType = "note" // a comment on the task above it
+	TemplateRowMeta    TemplateRowType = "meta" // a setting of the project, such as view_style=list
+)
+
+// templateColumns are the columns of Todoist's CSV template format, in the order they are written
+var templateColumns = []string{"TYPE", "CONTENT", "DESCRIPTION", "PRIORITY", "INDENT", "AUTHOR", "RESPONSIBLE", "DATE", "DATE_LANG", "TIMEZONE"}
+
+// TemplateRow is a single row of a project template
+type TemplateRow struct {
+	Type        TemplateRowType
+	Content     string
+	Description string
+	Priority    Priority // the API value, which the CSV stores the other way around, as 1 for p1
+	Indent      int      // 1 for a top level task, 2 for its subtasks, and so on
+	Author      string
+	Responsible string
+	Date        string // the due date in free form text, such as "every monday"
+	DateLang    string
+	Timezone    string
+}
+
+// ProjectTemplate is the structure of a project, in the rows of Todoist's CSV template format.
+// https://todoist.com/help/articles/how-to-format-your-csv-file-so-you-can-import-it-into-todoist
+type ProjectTemplate struct {
+	Rows []TemplateRow
+}
+
+// ParseTemplateCSV reads a project template in Todoist's CSV format. Columns are matched by their header, so missing or reordered
+// columns are fine, and blank rows are skipped.
+func ParseTemplateCSV(r io.Reader) (*ProjectTemplate, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("the template is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToUpper(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	if _, found := columns["TYPE"]; !found {
+		return nil, errors.New("the template has no TYPE column")
+	}
+
+	template := &ProjectTemplate{Rows: []TemplateRow{}}
+	// records are numbered with the header as row 1
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		field := func(name string) string {
+			if i, found := columns[name]; found && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		if strings.Join(record, "") == "" {
+			continue
+		}
+
+		row := TemplateRow{
+			Type:        TemplateRowType(strings.ToLower(field("TYPE"))),
+			Content:     field("CONTENT"),
+			Description: field("DESCRIPTION"),
+			Author:      field("AUTHOR"),
+			Responsible: field("RESPONSIBLE"),
+			Date:        field("DATE"),
+			DateLang:    field("DATE_LANG"),
+			Timezone:    field("TIMEZONE"),
+		}
+		switch row.Type {
+		case TemplateRowTask, TemplateRowSection, TemplateRowNote, TemplateRowMeta:
+		default:
+			return nil, fmt.Errorf("row %d: unknown type %q", line, row.Type)
+		}
+		if value := field("PRIORITY"); value != "" {
+			priority, err := strconv.Atoi(value)
+			if err != nil || priority < 1 || priority > 4 {
+				return nil, fmt.Errorf("row %d: priority must be between 1 and 4, not %q", line, value)
+			}
+			row.Priority = Priority(5 - priority)
+		}
+		if value := field("INDENT"); value != "" {
+			indent, err := strconv.Atoi(value)
+			if err != nil || indent < 1 {
+				return nil, fmt.Errorf("row %d: indent must be a positive number, not %q", line, value)
+			}
+			row.Indent = indent
+		}
+		template.Rows = append(template.Rows, row)
+	}
+	return template, nil
+}
+
+// WriteCSV writes the template in Todoist's CSV format
+func (t *ProjectTemplate) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(templateColumns); err != nil {
+		return err
+	}
+	for _, row := range t.Rows {
+		priority, indent := "", ""
+		if row.Priority != 0 {
+			priority = strconv.Itoa(5 - int(row.Priority))
+		}
+		if row.Indent != 0 {
+			indent = strconv.Itoa(row.Indent)
+		}
+		record := []string{string(row.Type), row.Content, row.Description, priority, indent, row.Author, row.Responsible, row.Date, row.DateLang, row.Timezone}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// BuildProjectTemplate builds a template from a project's sections and tasks that were already fetched, without asking Todoist. Tasks
+// without a section come first, followed by the tasks of sections that are not in the list, such as archived ones, so they are not lost.
+// Then come each section with its tasks, and subtasks follow their parent one indent deeper.
+func BuildProjectTemplate(sections []Section, tasks []Task) *ProjectTemplate {
+	template := &ProjectTemplate{Rows: []TemplateRow{}}
+
+	known := map[int64]bool{}
+	for i := range tasks {
+		known[tasks[i].ID] = true
+	}
+	children := map[int64][]Task{}
+	bySection := map[int64][]Task{}
+	for _, task := range tasks {
+		if task.ParentID != 0 && known[task.ParentID] {
+			children[task.ParentID] = append(children[task.ParentID], task)
+		} else {
+			bySection[task.SectionID] = append(bySection[task.SectionID], task)
+		}
+	}
+
+	var addTasks func(tasks []Task, indent int)
+	addTasks = func(tasks []Task, indent int) {
+		sort.SliceStable(tasks, func(i, j int) bool { return tasks[i].Order < tasks[j].Order })
+		for _, task := range tasks {
+			template.Rows = append(template.Rows, TemplateRow{
+				Type:        TemplateRowTask,
+				Content:     task.Content,
+				Description: task.Description,
+				Priority:    task.Priority,
+				Indent:      indent,
+				Date:        task.Due.String,
+				Timezone:    task.Due.Timezone,
+			})
+			addTasks(children[task.ID], indent+1)
+		}
+	}
+
+	addTasks(bySection[0], 1)
+	listed := map[int64]bool{}
+	for _, section := range sections {
+		listed[section.ID] = true
+	}
+	unlisted := []int64{}
+	for sectionID := range bySection {
+		if sectionID != 0 && !listed[sectionID] {
+			unlisted = append(unlisted, sectionID)
+		}
+	}
+	sort.Slice(unlisted, func(i, j int) bool { return unlisted[i] < unlisted[j] })
+	for _, sectionID := range unlisted {
+		addTasks(bySection[sectionID], 1)
+	}
+
+	sorted := append([]Section{}, sections...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Order < sorted[j].Order })
+	for _, section := range sorted {
+		template.Rows = append(template.Rows, TemplateRow{Type: TemplateRowSection, Content: section.Name})
+		addTasks(bySection[section.ID], 1)
+	}
+	return template
+}
+
+// AddToBatch queues the commands that recreate the template in the project, for importing it without the server's template endpoint.
+// The project may be one created earlier in the same batch. Meta rows are skipped, and the author and responsible columns are ignored.
+func (t *ProjectTemplate) AddToBatch(batch *CommandBatch, project Ref) error {
+	section := Ref{}
+	parents := []Ref{} // the last task at each indent
+	for i, row := range t.Rows {
+		switch row.Type {
+		case TemplateRowSection:
+			section = batch.CreateSection(&SectionParams{Name: row.Content}, project).Ref()
+			parents = parents[:0]
+		case TemplateRowTask:
+			indent := row.Indent
+			if indent == 0 {
+				indent = 1
+			}
+			if indent > len(parents)+1 {
+				return fmt.Errorf("row %d: indent %d skips a level", i+1, indent)
+			}
+			params := &TaskParams{Content: String(row.Content), Priority: row.Priority}
+			if row.Description != "" {
+				params.Description = String(row.Description)
+			}
+			if row.Date != "" {
+				params.DueString = String(row.Date)
+			}
+			if row.DateLang != "" {
+				params.DueLang = String(row.DateLang)
+			}
+			placement := Placement{Project: project, Section: section}
+			if indent > 1 {
+				placement.Parent = parents[indent-2]
+			}
+			parents = append(parents[:indent-1], batch.CreateTask(params, placement).Ref())
+		case TemplateRowNote:
+			if len(parents) == 0 {
+				return fmt.Errorf("row %d: a note must follow a task", i+1)
+			}
+			batch.Add("note_add", map[string]interface{}{"item_id": parents[len(parents)-1], "content": row.Content})
+		}
+	}
+	return nil
+}
+
+// ExportProjectTemplate gets the template of a project from Todoist. https://developer.todoist.com/sync/v8/#export-as-file
+func ExportProjectTemplate(token string, projectID int64) (*ProjectTemplate, error) {
+	return clientForToken(token).ExportProjectTemplate(projectID)
+}
+
+// ExportProjectTemplateWithContext is ExportProjectTemplate with a context that can cancel the call or set its deadline
+func ExportProjectTemplateWithContext(ctx context.Context, token string, projectID int64) (*ProjectTemplate, error) {
+	return clientForToken(token).ExportProjectTemplateWithContext(ctx, projectID)
+}
+
+// ImportProjectTemplate adds the sections and tasks of a template to an existing project. https://developer.todoist.com/sync/v8/#import-into-project
+func ImportProjectTemplate(token string, projectID int64, template *ProjectTemplate) error {
+	return clientForToken(token).ImportProjectTemplate(projectID, template)
+}
+
+// ImportProjectTemplateWithContext is ImportProjectTemplate with a context that can cancel the call or set its deadline
+func ImportProjectTemplateWithContext(ctx context.Context, token string, projectID int64, template *ProjectTemplate) error {
+	return clientForToken(token).ImportProjectTemplateWithContext(ctx, projectID, template)
+}
+
+// CreateProjectFromTemplate creates a project and imports the template into it
+func CreateProjectFromTemplate(token string, input *ProjectParams, template *ProjectTemplate) (*Project, error) {
+	return clientForToken(token).CreateProjectFromTemplate(input, template)
+}
+
+// CreateProjectFromTemplateWithContext is CreateProjectFromTemplate with a context that can cancel the call or set its deadline
+func CreateProjectFromTemplateWithContext(ctx context.Context, token string, input *ProjectParams, template *ProjectTemplate) (*Project, error) {
+	return clientForToken(token).CreateProjectFromTemplateWithContext(ctx, input, template)
+}
+
+// ExportProjectTemplate gets the template of a project from Todoist. https://developer.todoist.com/sync/v8/#export-as-file
+func (c *Client) ExportProjectTemplate(projectID int64) (*ProjectTemplate, error) {
+	return c.ExportProjectTemplateWithContext(context.Background(), projectID)
+}
+
+// ExportProjectTemplateWithContext is ExportProjectTemplate with a context that can cancel the call or set its deadline
+func (c *Client) ExportProjectTemplateWithContext(ctx context.Context, projectID int64) (*ProjectTemplate, error) {
+	if projectID == 0 {
+		return nil, errors.New("project id is required")
+	}
+	resp, err := c.makeCall(ctx, EndpointNameExportTemplate, map[string]string{}, map[string]string{
+		"project_id": fmt.Sprintf("%d", projectID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ParseTemplateCSV(bytes.NewReader(resp.Body))
+}
+
+// ImportProjectTemplate adds the sections and tasks of a template to an existing project. https://developer.todoist.com/sync/v8/#import-into-project
+func (c *Client) ImportProjectTemplate(projectID int64, template *ProjectTemplate) error {
+	return c.ImportProjectTemplateWithContext(context.Background(), projectID, template)
+}
+
+// ImportProjectTemplateWithContext is ImportProjectTemplate with a context that can cancel the call or set its deadline
+func (c *Client) ImportProjectTemplateWithContext(ctx context.Context, projectID int64, template *ProjectTemplate) error {
+	if projectID == 0 {
+		return errors.New("project id is required")
+	}
+	if template == nil {
+		return errors.New("template is required")
+	}
+	file := &bytes.Buffer{}
+	if err := template.WriteCSV(file); err != nil {
+		return err
+	}
+	_, err := c.makeCall(ctx, EndpointNameImportTemplate, map[string]string{}, multipartBody{
+		Fields:    map[string]string{"project_id": fmt.Sprintf("%d", projectID)},
+		FileParam: "file",
+		FileName:  "template.csv",
+		File:      file.Bytes(),
+	})
+	return err
+}
+
+// CreateProjectFromTemplate creates a project and imports the template into it
+func (c *Client) CreateProjectFromTemplate(input *ProjectParams, template *ProjectTemplate) (*Project, error) {
+	return c.CreateProjectFromTemplateWithContext(context.Background(), input, template)
+}
+
+// CreateProjectFromTemplateWithContext is CreateProjectFromTemplate with a context that can cancel the call or set its deadline
+func (c *Client) CreateProjectFromTemplateWithContext(ctx context.Context, input *ProjectParams, template *ProjectTemplate) (*Project, error) {
+	if template == nil {
+		return nil, errors.New("template is required")
+	}
+	project, err := c.CreateProjectWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.ImportProjectTemplateWithContext(ctx, project.ID, template); err != nil {
+		return project, err
+	}
+	return project, nil
+}
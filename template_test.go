@@ -0,0 +1,163 @@
+package todoist
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testTemplateCSV = "\ufeffTYPE,CONTENT,DESCRIPTION,PRIORITY,INDENT,AUTHOR,RESPONSIBLE,DATE,DATE_LANG,TIMEZONE\n" +
+	"meta,view_style=list,,,,,,,,\n" +
+	"task,Kickoff call,Agenda in the doc,1,1,Alex (1),,tomorrow,en,\n" +
+	"note,Bring the contract,,,,,,,,\n" +
+	"task,Send invite,,4,2,,,,,\n" +
+	",,,,,,,,,\n" +
+	"section,Delivery,,,,,,,,\n" +
+	"task,\"Draft, review, ship\",,2,1,,,every monday,en,Europe/Berlin\n"
+
+func TestParseTemplateCSV(t *testing.T) {
+	template, err := ParseTemplateCSV(strings.NewReader(testTemplateCSV))
+	require.Nil(t, err)
+	require.Len(t, template.Rows, 6)
+	assert.Equal(t, TemplateRow{Type: TemplateRowMeta, Content: "view_style=list"}, template.Rows[0])
+	assert.Equal(t, TemplateRow{
+		Type:        TemplateRowTask,
+		Content:     "Kickoff call",
+		Description: "Agenda in the doc",
+		Priority:    PriorityUrgent,
+		Indent:      1,
+		Author:      "Alex (1)",
+		Date:        "tomorrow",
+		DateLang:    "en",
+	}, template.Rows[1])
+	assert.Equal(t, TemplateRowNote, template.Rows[2].Type)
+	assert.Equal(t, PriorityNormal, template.Rows[3].Priority)
+	assert.Equal(t, 2, template.Rows[3].Indent)
+	assert.Equal(t, TemplateRow{Type: TemplateRowSection, Content: "Delivery"}, template.Rows[4])
+	assert.Equal(t, "Draft, review, ship", template.Rows[5].Content)
+	assert.Equal(t, PriorityHigher, template.Rows[5].Priority)
+
+	// a round trip keeps every row
+	out := &bytes.Buffer{}
+	require.Nil(t, template.WriteCSV(out))
+	again, err := ParseTemplateCSV(out)
+	require.Nil(t, err)
+	assert.Equal(t, template, again)
+
+	// columns are matched by name
+	template, err = ParseTemplateCSV(strings.NewReader("content,type\nJust a task,task\n"))
+	require.Nil(t, err)
+	assert.Equal(t, []TemplateRow{{Type: TemplateRowTask, Content: "Just a task"}}, template.Rows)
+
+	for input, message := range map[string]string{
+		"":                                  "the template is empty",
+		"CONTENT\nx\n":                      "the template has no TYPE column",
+		"TYPE,CONTENT\ntask,a\nproject,b\n": `row 3: unknown type "project"`,
+		"TYPE,PRIORITY\ntask,5\n":           `row 2: priority must be between 1 and 4, not "5"`,
+		"TYPE,INDENT\ntask,0\n":             `row 2: indent must be a positive number, not "0"`,
+	} {
+		_, err = ParseTemplateCSV(strings.NewReader(input))
+		require.NotNil(t, err, input)
+		assert.Equal(t, message, err.Error(), input)
+	}
+}
+
+func TestBuildProjectTemplate(t *testing.T) {
+	sections := []Section{{ID: 20, Name: "Later", Order: 2}, {ID: 10, Name: "Now", Order: 1}}
+	tasks := []Task{
+		{ID: 1, Content: "Loose", Order: 1, Priority: PriorityNormal},
+		{ID: 2, SectionID: 10, Content: "Second", Order: 2, Priority: PriorityNormal},
+		{ID: 3, SectionID: 10, Content: "First", Order: 1, Priority: PriorityUrgent, Due: TaskDueInfo{String: "today"}},
+		{ID: 4, SectionID: 10, ParentID: 3, Content: "Sub", Order: 1, Priority: PriorityNormal},
+		{ID: 5, SectionID: 20, Content: "Eventually", Order: 1, Priority: PriorityNormal, Description: "Some day"},
+	}
+	template := BuildProjectTemplate(sections, tasks)
+	summary := []string{}
+	for _, row := range template.Rows {
+		summary = append(summary, strings.Repeat(">", row.Indent)+string(row.Type)+":"+row.Content)
+	}
+	assert.Equal(t, []string{">task:Loose", "section:Now", ">task:First", ">>task:Sub", ">task:Second", "section:Later", ">task:Eventually"}, summary)
+	assert.Equal(t, "today", template.Rows[2].Date)
+	assert.Equal(t, PriorityUrgent, template.Rows[2].Priority)
+	assert.Equal(t, "Some day", template.Rows[6].Description)
+
+	// the tasks of an unlisted section, such as an archived one, are kept as unsectioned rows along with their subtasks
+	tasks = append(tasks,
+		Task{ID: 6, SectionID: 30, Content: "Archived", Order: 1},
+		Task{ID: 7, SectionID: 30, ParentID: 6, Content: "Archived sub", Order: 1},
+	)
+	template = BuildProjectTemplate(sections, tasks)
+	summary = []string{}
+	for _, row := range template.Rows {
+		summary = append(summary, strings.Repeat(">", row.Indent)+string(row.Type)+":"+row.Content)
+	}
+	assert.Equal(t, []string{">task:Loose", ">task:Archived", ">>task:Archived sub", "section:Now", ">task:First", ">>task:Sub", ">task:Second",
+		"section:Later", ">task:Eventually"}, summary)
+}
+
+func TestProjectTemplateAddToBatch(t *testing.T) {
+	template, err := ParseTemplateCSV(strings.NewReader(testTemplateCSV))
+	require.Nil(t, err)
+
+	batch := NewCommandBatch()
+	project := batch.CreateProject(&ProjectParams{Name: String("Client X")}, Ref{})
+	require.Nil(t, template.AddToBatch(batch, project.Ref()))
+
+	commands := batch.Commands()
+	types := []string{}
+	for _, cmd := range commands {
+		types = append(types, cmd.Type)
+	}
+	assert.Equal(t, []string{"project_add", "item_add", "note_add", "item_add", "section_add", "item_add"}, types)
+	kickoff, invite, section, draft := commands[1], commands[3], commands[4], commands[5]
+	assert.Equal(t, project.Ref(), kickoff.Args["project_id"])
+	assert.Equal(t, PriorityUrgent, kickoff.Args["priority"])
+	assert.Equal(t, map[string]interface{}{"string": "tomorrow", "lang": "en"}, kickoff.Args["due"])
+	assert.Equal(t, kickoff.Ref(), commands[2].Args["item_id"])
+	assert.Equal(t, kickoff.Ref(), invite.Args["parent_id"])
+	assert.Equal(t, section.Ref(), draft.Args["section_id"])
+	assert.Nil(t, draft.Args["parent_id"])
+
+	bad := &ProjectTemplate{Rows: []TemplateRow{{Type: TemplateRowTask, Content: "Deep", Indent: 2}}}
+	assert.NotNil(t, bad.AddToBatch(NewCommandBatch(), IDRef(1)))
+	bad = &ProjectTemplate{Rows: []TemplateRow{{Type: TemplateRowNote, Content: "Orphan"}}}
+	assert.NotNil(t, bad.AddToBatch(NewCommandBatch(), IDRef(1)))
+}
+
+func TestProjectTemplateServer(t *testing.T) {
+	fake, client := newFakeTodoistClient(t)
+	fake.seed("projects",
+		map[string]interface{}{"id": 2, "name": "Client X"},
+		map[string]interface{}{"id": 4, "name": "Empty"},
+	)
+	fake.templates[2] = []byte(testTemplateCSV)
+
+	template, err := client.ExportProjectTemplate(2)
+	require.Nil(t, err)
+	assert.Len(t, template.Rows, 6)
+
+	require.Nil(t, client.ImportProjectTemplate(4, template))
+	imports := fake.calls("templates/import_into_project")
+	require.Len(t, imports, 1)
+	assert.Equal(t, "4", imports[0].Get("project_id"))
+	again, err := ParseTemplateCSV(bytes.NewReader(fake.templates[4]))
+	require.Nil(t, err)
+	assert.Equal(t, template, again)
+
+	project, err := client.CreateProjectFromTemplate(&ProjectParams{Name: String("Client Y")}, template)
+	require.Nil(t, err)
+	assert.Equal(t, "Client Y", project.Name)
+	again, err = client.ExportProjectTemplate(project.ID)
+	require.Nil(t, err)
+	assert.Equal(t, template, again)
+
+	assert.NotNil(t, client.ImportProjectTemplate(404, template))
+	assert.NotNil(t, client.ImportProjectTemplate(0, template))
+	assert.NotNil(t, client.ImportProjectTemplate(2, nil))
+	_, err = client.ExportProjectTemplate(0)
+	assert.NotNil(t, err)
+	assert.Len(t, fake.calls("templates/import_into_project"), 3)
+}
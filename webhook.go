@@ -0,0 +1,312 @@
+package todoist
+
+import (
+	"container/list"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WebhookEventName identifies what happened in a webhook delivery. https://developer.todoist.com/sync/v8/#configuration
+type WebhookEventName string
+
+const (
+	WebhookEventItemAdded       WebhookEventName = "item:added"
+	WebhookEventItemUpdated     WebhookEventName = "item:updated"
+	WebhookEventItemDeleted     WebhookEventName = "item:deleted"
+	WebhookEventItemCompleted   WebhookEventName = "item:completed"
+	WebhookEventItemUncompleted WebhookEventName = "item:uncompleted"
+
+	WebhookEventNoteAdded   WebhookEventName = "note:added"
+	WebhookEventNoteUpdated WebhookEventName = "note:updated"
+	WebhookEventNoteDeleted WebhookEventName = "note:deleted"
+
+	WebhookEventProjectAdded      WebhookEventName = "project:added"
+	WebhookEventProjectUpdated    WebhookEventName = "project:updated"
+	WebhookEventProjectDeleted    WebhookEventName = "project:deleted"
+	WebhookEventProjectArchived   WebhookEventName = "project:archived"
+	WebhookEventProjectUnarchived WebhookEventName = "project:unarchived"
+
+	WebhookEventSectionAdded      WebhookEventName = "section:added"
+	WebhookEventSectionUpdated    WebhookEventName = "section:updated"
+	WebhookEventSectionDeleted    WebhookEventName = "section:deleted"
+	WebhookEventSectionArchived   WebhookEventName = "section:archived"
+	WebhookEventSectionUnarchived WebhookEventName = "section:unarchived"
+
+	WebhookEventLabelAdded   WebhookEventName = "label:added"
+	WebhookEventLabelUpdated WebhookEventName = "label:updated"
+	WebhookEventLabelDeleted WebhookEventName = "label:deleted"
+)
+
+const (
+	// WebhookSignatureHeader holds the base64 encoded HMAC-SHA256 of the body, keyed with the app's client secret
+	WebhookSignatureHeader = "X-Todoist-Hmac-SHA256"
+	// WebhookDeliveryIDHeader holds the id of the delivery, which stays the same when Todoist redelivers it
+	WebhookDeliveryIDHeader = "X-Todoist-Delivery-ID"
+)
+
+var (
+	// ErrInvalidWebhookSignature means the request was not signed with the app's client secret
+	ErrInvalidWebhookSignature = errors.New("todoist: invalid webhook signature")
+	// ErrWebhookReplay means the delivery has already been received
+	ErrWebhookReplay = errors.New("todoist: webhook delivery already received")
+)
+
+// WebhookInitiator is the user whose action caused the event
+type WebhookInitiator struct {
+	ID        int64  `json:"id"`
+	Email     string `json:"email"`
+	FullName  string `json:"full_name"`
+	ImageID   string `json:"image_id"`
+	IsPremium bool   `json:"is_premium"`
+}
+
+// WebhookEvent is a single webhook delivery. EventData holds the raw object, and depending on the event name, exactly one of Task,
+// Comment, Project, Section, or Label is decoded from it.
+type WebhookEvent struct {
+	Name       WebhookEventName `json:"event_name"`
+	UserID     int64            `json:"user_id"`
+	Initiator  WebhookInitiator `json:"initiator"`
+	Version    string           `json:"version"`
+	EventData  json.RawMessage  `json:"event_data"`
+	DeliveryID string           `json:"-"`
+
+	Task    *Task    `json:"-"`
+	Comment *Comment `json:"-"`
+	Project *Project `json:"-"`
+	Section *Section `json:"-"`
+	Label   *Label   `json:"-"`
+}
+
+// ParseWebhookRequest verifies the signature of a webhook request against the app's client secret and decodes its event. It does not
+// protect against replays; use a WebhookReceiver for that.
+func ParseWebhookRequest(r *http.Request, clientSecret string) (*WebhookEvent, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBodySize))
+	if err != nil {
+		return nil, err
+	}
+	if !VerifyWebhookSignature(body, r.Header.Get(WebhookSignatureHeader), clientSecret) {
+		return nil, ErrInvalidWebhookSignature
+	}
+	event, err := decodeWebhookEvent(body)
+	if err != nil {
+		return nil, err
+	}
+	event.DeliveryID = r.Header.Get(WebhookDeliveryIDHeader)
+	return event, nil
+}
+
+// maxWebhookBodySize bounds how much of a webhook request is read
+const maxWebhookBodySize = 1 << 20
+
+// VerifyWebhookSignature reports whether the signature is the base64 encoded HMAC-SHA256 of the body, keyed with the client secret
+func VerifyWebhookSignature(body []byte, signature string, clientSecret string) bool {
+	if signature == "" || clientSecret == "" {
+		return false
+	}
+	expected, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(clientSecret))
+	mac.Write(body)
+	return hmac.Equal(expected, mac.Sum(nil))
+}
+
+func decodeWebhookEvent(body []byte) (*WebhookEvent, error) {
+	event := &WebhookEvent{}
+	if err := json.Unmarshal(body, event); err != nil {
+		return nil, err
+	}
+	if event.Name == "" {
+		return nil, errors.New("the webhook payload has no event_name")
+	}
+
+	var err error
+	switch strings.SplitN(string(event.Name), ":", 2)[0] {
+	case "item":
+		item := syncItem{}
+		if err = json.Unmarshal(event.EventData, &item); err == nil {
+			task := item.toTask()
+			event.Task = &task
+		}
+	case "note":
+		note := syncNote{}
+		if err = json.Unmarshal(event.EventData, &note); err == nil {
+			comment := note.toComment()
+			event.Comment = &comment
+		}
+	case "project":
+		project := syncProject{}
+		if err = json.Unmarshal(event.EventData, &project); err == nil {
+			converted := project.toProject()
+			event.Project = &converted
+		}
+	case "section":
+		section := syncSection{}
+		if err = json.Unmarshal(event.EventData, &section); err == nil {
+			converted := section.toSection()
+			event.Section = &converted
+		}
+	case "label":
+		label := syncLabel{}
+		if err = json.Unmarshal(event.EventData, &label); err == nil {
+			converted := label.toLabel()
+			event.Label = &converted
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not decode the event_data of %s: %w", event.Name, err)
+	}
+	return event, nil
+}
+
+// WebhookHandlerFunc handles a webhook event. Returning an error makes the receiver answer with a 500, so Todoist delivers the event
+// again later.
+type WebhookHandlerFunc func(event *WebhookEvent) error
+
+// WebhookReceiver is an http.Handler for Todoist webhooks. It verifies each request's signature, rejects deliveries it has already
+// received, decodes the event, and calls the handlers registered for the event name.
+type WebhookReceiver struct {
+	clientSecret string
+	replayWindow time.Duration
+
+	lock       sync.Mutex
+	handlers   map[WebhookEventName][]WebhookHandlerFunc
+	anyHandler []WebhookHandlerFunc
+	deliveries map[string]*list.Element // of the delivery order
+	// deliveryOrder holds the remembered deliveries oldest first, so the expired ones are dropped from the front
+	deliveryOrder *list.List
+}
+
+// webhookDelivery is a remembered delivery id and when it was received
+type webhookDelivery struct {
+	id       string
+	received time.Time
+}
+
+// WebhookReceiverOption configures a WebhookReceiver when it is created with NewWebhookReceiver
+type WebhookReceiverOption func(*WebhookReceiver)
+
+// WithReplayWindow sets how long delivery ids are remembered to reject replays. The default is 24 hours.
+func WithReplayWindow(window time.Duration) WebhookReceiverOption {
+	return func(w *WebhookReceiver) {
+		w.replayWindow = window
+	}
+}
+
+// NewWebhookReceiver creates a receiver that verifies requests with the app's client secret
+func NewWebhookReceiver(clientSecret string, opts ...WebhookReceiverOption) *WebhookReceiver {
+	w := &WebhookReceiver{
+		clientSecret:  clientSecret,
+		replayWindow:  24 * time.Hour,
+		handlers:      map[WebhookEventName][]WebhookHandlerFunc{},
+		deliveries:    map[string]*list.Element{},
+		deliveryOrder: list.New(),
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+// On registers a handler for one event name. Handlers run in the order they were registered.
+func (w *WebhookReceiver) On(name WebhookEventName, handler WebhookHandlerFunc) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.handlers[name] = append(w.handlers[name], handler)
+}
+
+// OnAny registers a handler for every event, which runs after the handlers for the specific event name
+func (w *WebhookReceiver) OnAny(handler WebhookHandlerFunc) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.anyHandler = append(w.anyHandler, handler)
+}
+
+// Parse verifies and decodes a webhook request, returning ErrWebhookReplay if the delivery was already received
+func (w *WebhookReceiver) Parse(r *http.Request) (*WebhookEvent, error) {
+	event, err := ParseWebhookRequest(r, w.clientSecret)
+	if err != nil {
+		return nil, err
+	}
+	if !w.remember(event.DeliveryID) {
+		return nil, ErrWebhookReplay
+	}
+	return event, nil
+}
+
+// ServeHTTP answers 401 for bad signatures, 400 for undecodable payloads, 200 for replays so Todoist stops sending them, and 500 if a
+// handler fails
+func (w *WebhookReceiver) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		rw.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	event, err := w.Parse(r)
+	switch {
+	case errors.Is(err, ErrInvalidWebhookSignature):
+		http.Error(rw, err.Error(), http.StatusUnauthorized)
+		return
+	case errors.Is(err, ErrWebhookReplay):
+		rw.WriteHeader(http.StatusOK)
+		return
+	case err != nil:
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.lock.Lock()
+	handlers := append(append([]WebhookHandlerFunc{}, w.handlers[event.Name]...), w.anyHandler...)
+	w.lock.Unlock()
+	for _, handler := range handlers {
+		if err := handler(event); err != nil {
+			// let the redelivery through
+			w.forget(event.DeliveryID)
+			http.Error(rw, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	rw.WriteHeader(http.StatusOK)
+}
+
+// remember records the delivery id, returning false if it was already recorded within the replay window. Deliveries without an id are
+// always accepted.
+func (w *WebhookReceiver) remember(deliveryID string) bool {
+	if deliveryID == "" {
+		return true
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	now := time.Now()
+	for front := w.deliveryOrder.Front(); front != nil; front = w.deliveryOrder.Front() {
+		delivery := front.Value.(webhookDelivery)
+		if now.Sub(delivery.received) <= w.replayWindow {
+			break
+		}
+		w.deliveryOrder.Remove(front)
+		delete(w.deliveries, delivery.id)
+	}
+	if _, found := w.deliveries[deliveryID]; found {
+		return false
+	}
+	w.deliveries[deliveryID] = w.deliveryOrder.PushBack(webhookDelivery{id: deliveryID, received: now})
+	return true
+}
+
+func (w *WebhookReceiver) forget(deliveryID string) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if element, found := w.deliveries[deliveryID]; found {
+		w.deliveryOrder.Remove(element)
+		delete(w.deliveries, deliveryID)
+	}
+}
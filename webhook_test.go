@@ -0,0 +1,198 @@
+package todoist
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testWebhookSecret = "platypus-secret"
+
+func signWebhook(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func newWebhookRequest(body string, secret string, deliveryID string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/webhooks/todoist", bytes.NewBufferString(body))
+	r.Header.Set(WebhookSignatureHeader, signWebhook([]byte(body), secret))
+	r.Header.Set(WebhookDeliveryIDHeader, deliveryID)
+	return r
+}
+
+const testItemAddedPayload = `{
+	"event_name": "item:added",
+	"user_id": 2671355,
+	"version": "8",
+	"initiator": {"id": 2671355, "email": "ada@example.com", "full_name": "Ada Lovelace", "is_premium": true},
+	"event_data": {
+		"id": 2995104339,
+		"project_id": 2203306141,
+		"section_id": 7025,
+		"content": "Buy Milk",
+		"description": "",
+		"priority": 4,
+		"due": {"date": "2021-06-01T17:00:00", "timezone": null, "string": "tomorrow 5pm", "lang": "en", "is_recurring": false},
+		"parent_id": null,
+		"child_order": 3,
+		"labels": [2156154810],
+		"responsible_uid": 2,
+		"assigned_by_uid": 1,
+		"checked": 0,
+		"is_deleted": 0
+	}
+}`
+
+func TestParseWebhookRequest(t *testing.T) {
+	event, err := ParseWebhookRequest(newWebhookRequest(testItemAddedPayload, testWebhookSecret, "delivery-1"), testWebhookSecret)
+	require.Nil(t, err)
+	require.NotNil(t, event)
+	assert.Equal(t, WebhookEventItemAdded, event.Name)
+	assert.Equal(t, int64(2671355), event.UserID)
+	assert.Equal(t, "Ada Lovelace", event.Initiator.FullName)
+	assert.Equal(t, "delivery-1", event.DeliveryID)
+	require.NotNil(t, event.Task)
+	assert.Nil(t, event.Project)
+	assert.Equal(t, int64(2995104339), event.Task.ID)
+	assert.Equal(t, int64(2203306141), event.Task.ProjectID)
+	assert.Equal(t, int64(7025), event.Task.SectionID)
+	assert.Equal(t, "Buy Milk", event.Task.Content)
+	assert.Equal(t, PriorityUrgent, event.Task.Priority)
+	assert.Equal(t, "2021-06-01", event.Task.Due.Date)
+	assert.Equal(t, "2021-06-01T17:00:00", event.Task.Due.Datetime)
+	assert.Equal(t, []int64{2156154810}, event.Task.LabelIDs)
+	assert.Equal(t, int64(3), event.Task.Order)
+	assert.Equal(t, int64(2), event.Task.Assignee)
+	assert.Equal(t, int64(1), event.Task.Assigner)
+	assert.False(t, event.Task.Completed)
+
+	// signed with the wrong secret, or not at all
+	_, err = ParseWebhookRequest(newWebhookRequest(testItemAddedPayload, "wrong", "delivery-1"), testWebhookSecret)
+	assert.True(t, errors.Is(err, ErrInvalidWebhookSignature))
+	r := newWebhookRequest(testItemAddedPayload, testWebhookSecret, "delivery-1")
+	r.Header.Del(WebhookSignatureHeader)
+	_, err = ParseWebhookRequest(r, testWebhookSecret)
+	assert.True(t, errors.Is(err, ErrInvalidWebhookSignature))
+
+	// signed, but not an event
+	_, err = ParseWebhookRequest(newWebhookRequest(`{"user_id": 1}`, testWebhookSecret, "delivery-2"), testWebhookSecret)
+	assert.NotNil(t, err)
+	_, err = ParseWebhookRequest(newWebhookRequest(`{"event_name": "item:added", "event_data": {"id": "abc"}}`, testWebhookSecret, "delivery-3"), testWebhookSecret)
+	assert.NotNil(t, err)
+}
+
+func TestWebhookEventTypes(t *testing.T) {
+	payloads := map[string]func(*WebhookEvent){
+		`{"event_name": "note:added", "event_data": {"id": 1, "item_id": 2, "project_id": 3, "content": "Looks good", "file_attachment": {"file_name": "a.png", "resource_type": "image"}}}`: func(event *WebhookEvent) {
+			require.NotNil(t, event.Comment)
+			assert.Equal(t, int64(2), event.Comment.TaskID)
+			assert.Zero(t, event.Comment.ProjectID)
+			assert.Equal(t, "a.png", event.Comment.Attachment.FileName)
+		},
+		`{"event_name": "project:updated", "event_data": {"id": 3, "name": "Work", "color": 41, "is_favorite": 1, "shared": true, "child_order": 2}}`: func(event *WebhookEvent) {
+			require.NotNil(t, event.Project)
+			assert.Equal(t, "Work", event.Project.Name)
+			assert.Equal(t, ColorBlue, event.Project.Color)
+			assert.True(t, event.Project.Favorite)
+			assert.True(t, event.Project.Shared)
+			assert.Equal(t, int64(2), event.Project.Order)
+		},
+		`{"event_name": "section:added", "event_data": {"id": 4, "name": "Doing", "project_id": 3, "section_order": 1}}`: func(event *WebhookEvent) {
+			require.NotNil(t, event.Section)
+			assert.Equal(t, "Doing", event.Section.Name)
+			assert.Equal(t, int64(3), event.Section.ProjectID)
+		},
+		`{"event_name": "label:deleted", "event_data": {"id": 5, "name": "waiting", "item_order": 7, "is_deleted": 1}}`: func(event *WebhookEvent) {
+			require.NotNil(t, event.Label)
+			assert.Equal(t, "waiting", event.Label.Name)
+			assert.Equal(t, int64(7), event.Label.Order)
+		},
+		`{"event_name": "reminder:fired", "event_data": {"id": 6}}`: func(event *WebhookEvent) {
+			assert.Nil(t, event.Task)
+			assert.NotEmpty(t, event.EventData)
+		},
+	}
+	for payload, check := range payloads {
+		event, err := ParseWebhookRequest(newWebhookRequest(payload, testWebhookSecret, ""), testWebhookSecret)
+		require.Nil(t, err, payload)
+		check(event)
+	}
+}
+
+func TestWebhookReceiver(t *testing.T) {
+	receiver := NewWebhookReceiver(testWebhookSecret)
+	added := []*WebhookEvent{}
+	all := 0
+	fail := false
+	receiver.On(WebhookEventItemAdded, func(event *WebhookEvent) error {
+		if fail {
+			return errors.New("the database is down")
+		}
+		added = append(added, event)
+		return nil
+	})
+	receiver.On(WebhookEventItemCompleted, func(event *WebhookEvent) error {
+		t.Error("the completed handler should not be called")
+		return nil
+	})
+	receiver.OnAny(func(event *WebhookEvent) error {
+		all++
+		return nil
+	})
+
+	send := func(r *http.Request) int {
+		w := httptest.NewRecorder()
+		receiver.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusOK, send(newWebhookRequest(testItemAddedPayload, testWebhookSecret, "delivery-1")))
+	require.Len(t, added, 1)
+	assert.Equal(t, "Buy Milk", added[0].Task.Content)
+	assert.Equal(t, 1, all)
+
+	// the same delivery again is acknowledged, but not handled
+	assert.Equal(t, http.StatusOK, send(newWebhookRequest(testItemAddedPayload, testWebhookSecret, "delivery-1")))
+	assert.Len(t, added, 1)
+	_, err := receiver.Parse(newWebhookRequest(testItemAddedPayload, testWebhookSecret, "delivery-1"))
+	assert.True(t, errors.Is(err, ErrWebhookReplay))
+
+	assert.Equal(t, http.StatusUnauthorized, send(newWebhookRequest(testItemAddedPayload, "wrong", "delivery-2")))
+	assert.Equal(t, http.StatusBadRequest, send(newWebhookRequest(`{}`, testWebhookSecret, "delivery-3")))
+	assert.Equal(t, http.StatusMethodNotAllowed, send(httptest.NewRequest(http.MethodGet, "/webhooks/todoist", nil)))
+
+	// a failed handler lets the redelivery through
+	fail = true
+	assert.Equal(t, http.StatusInternalServerError, send(newWebhookRequest(testItemAddedPayload, testWebhookSecret, "delivery-4")))
+	fail = false
+	assert.Equal(t, http.StatusOK, send(newWebhookRequest(testItemAddedPayload, testWebhookSecret, "delivery-4")))
+	assert.Len(t, added, 2)
+
+	// delivery ids are forgotten after the window
+	receiver = NewWebhookReceiver(testWebhookSecret, WithReplayWindow(0))
+	assert.True(t, receiver.remember("delivery-1"))
+	assert.True(t, receiver.remember("delivery-1"))
+
+	// expired ids are dropped oldest first, and forgotten ids leave the order too
+	receiver = NewWebhookReceiver(testWebhookSecret, WithReplayWindow(time.Hour))
+	for _, id := range []string{"delivery-1", "delivery-2", "delivery-3"} {
+		require.True(t, receiver.remember(id))
+	}
+	receiver.forget("delivery-2")
+	assert.Equal(t, 2, receiver.deliveryOrder.Len())
+	receiver.deliveryOrder.Front().Value = webhookDelivery{id: "delivery-1", received: time.Now().Add(-2 * time.Hour)}
+	assert.True(t, receiver.remember("delivery-2"))
+	assert.Len(t, receiver.deliveries, 2)
+	assert.Equal(t, "delivery-3", receiver.deliveryOrder.Front().Value.(webhookDelivery).id)
+	assert.False(t, receiver.remember("delivery-3"))
+}